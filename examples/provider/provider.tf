@@ -1,6 +1,20 @@
 provider "looker" {
   // required
+  base_url = "..."
+
+  // authentication: either client credentials, access_token or access_token_file
   client_id     = "..."
   client_secret = "..."
-  base_url      = "..."
+
+  // optional: retry throttled (429) and unavailable (502, 503, 504) responses
+  max_retries    = 4
+  retry_wait_min = 1
+  retry_wait_max = 30
+
+  // optional: throttle the requests sent to Looker during large applies
+  max_requests_per_second = 10
+  max_concurrent_requests = 4
+
+  // optional: API calls a resource makes at the same time, e.g. when adding the members of a large group
+  bulk_concurrency = 8
 }
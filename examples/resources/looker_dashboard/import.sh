@@ -0,0 +1,2 @@
+# dashboard can be imported using the dashboard ID
+terraform import looker_dashboard.sales <dashboard_id>
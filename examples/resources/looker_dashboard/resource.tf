@@ -0,0 +1,41 @@
+resource "looker_dashboard" "sales" {
+  title            = "Sales"
+  description      = "Daily sales by country"
+  folder_id        = looker_folder.sales.id
+  refresh_interval = "1 hour"
+
+  // other writable properties of the dashboard
+  dashboard_json = jsonencode({
+    show_title     = true
+    query_timezone = "UTC"
+  })
+
+  filter {
+    name          = "date"
+    title         = "Date"
+    type          = "date"
+    default_value = "7 days"
+  }
+
+  filter {
+    name      = "country"
+    title     = "Country"
+    type      = "field"
+    model     = "ecommerce"
+    explore   = "orders"
+    dimension = "users.country"
+  }
+}
+
+// a dashboard imported from LookML, e.g. exported from another instance
+resource "looker_dashboard" "from_lookml" {
+  folder_id = looker_folder.sales.id
+  lookml    = file("${path.module}/dashboards/revenue.dashboard.lookml")
+}
+
+// share the dashboard with a group
+resource "looker_content_metadata_access" "sales" {
+  content_metadata_id = looker_dashboard.sales.content_metadata_id
+  group_id            = looker_group.sales.id
+  permission_type     = "view"
+}
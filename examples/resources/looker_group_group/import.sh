@@ -0,0 +1,2 @@
+# group group can be imported using the parent group ID and the group ID separated by a colon
+terraform import looker_group_group.group_group <parent_group_id>:<group_id>
@@ -0,0 +1,4 @@
+resource "looker_group_group" "group_group" {
+  parent_group_id = looker_group.parent.id
+  group_id        = looker_group.group.id
+}
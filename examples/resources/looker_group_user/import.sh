@@ -0,0 +1,2 @@
+# group user can be imported using the group ID and the user ID separated by a colon
+terraform import looker_group_user.group_user <group_id>:<user_id>
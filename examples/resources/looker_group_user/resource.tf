@@ -0,0 +1,4 @@
+resource "looker_group_user" "group_user" {
+  group_id = looker_group.group.id
+  user_id  = looker_user.user.id
+}
@@ -0,0 +1,2 @@
+# look can be imported using the look ID
+terraform import looker_look.orders <look_id>
@@ -0,0 +1,25 @@
+resource "looker_look" "orders" {
+  title          = "Completed orders"
+  description    = "Completed orders per day over the last 30 days"
+  folder_id      = looker_folder.sales.id
+  is_run_on_load = true
+
+  query {
+    model  = "ecommerce"
+    view   = "orders"
+    fields = ["orders.created_date", "orders.count"]
+    filters = {
+      "orders.status"       = "complete"
+      "orders.created_date" = "30 days"
+    }
+    sorts = ["orders.created_date desc"]
+    limit = "500"
+
+    // custom fields and table calculations, as saved by the Explore
+    dynamic_fields = jsonencode([{
+      table_calculation = "share"
+      label             = "Share"
+      expression        = "$${orders.count} / sum($${orders.count})"
+    }])
+  }
+}
@@ -0,0 +1,2 @@
+# project can be imported using the project name
+terraform import looker_project.analytics <project_name>
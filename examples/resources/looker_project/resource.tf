@@ -0,0 +1,14 @@
+resource "looker_project" "analytics" {
+  name                       = "analytics"
+  git_remote_url             = "git@github.com:example/analytics.git"
+  git_production_branch_name = "main"
+  pull_request_mode          = "required"
+  validation_required        = true
+  allow_warnings             = false
+}
+
+resource "looker_lookml_model" "analytics" {
+  name                        = "analytics"
+  allowed_db_connection_names = [looker_connection.warehouse.name]
+  project_name                = looker_project.analytics.name
+}
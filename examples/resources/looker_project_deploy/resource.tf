@@ -0,0 +1,15 @@
+variable "lookml_commit" {
+  type        = string
+  description = "Commit of the LookML repository to deploy, set by the CI pipeline after merges."
+}
+
+resource "looker_project_deploy" "analytics" {
+  project_id             = looker_project.analytics.id
+  ref                    = var.lookml_commit
+  validate_before_deploy = true
+
+  timeouts {
+    create = "20m"
+    update = "20m"
+  }
+}
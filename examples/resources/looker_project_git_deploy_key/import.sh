@@ -0,0 +1,2 @@
+# project_git_deploy_key can be imported using the project name
+terraform import looker_project_git_deploy_key.analytics <project_name>
@@ -0,0 +1,11 @@
+resource "looker_project_git_deploy_key" "analytics" {
+  project_id = looker_project.analytics.id
+}
+
+// let Looker access the repository of the project
+resource "github_repository_deploy_key" "looker" {
+  title      = "Looker"
+  repository = "analytics"
+  key        = looker_project_git_deploy_key.analytics.public_key
+  read_only  = false
+}
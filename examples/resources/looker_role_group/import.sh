@@ -0,0 +1,2 @@
+# role group can be imported using the role ID and the group ID separated by a colon
+terraform import looker_role_group.role_group <role_id>:<group_id>
@@ -0,0 +1,4 @@
+resource "looker_role_group" "role_group" {
+  role_id  = looker_role.role.id
+  group_id = looker_group.group.id
+}
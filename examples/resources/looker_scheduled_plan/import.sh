@@ -0,0 +1,2 @@
+# scheduled_plan can be imported using the scheduled plan ID
+terraform import looker_scheduled_plan.weekly_sales <scheduled_plan_id>
@@ -0,0 +1,44 @@
+resource "looker_scheduled_plan" "weekly_sales" {
+  name           = "Weekly sales"
+  dashboard_id   = looker_dashboard.sales.id
+  crontab        = "0 9 * * 1"
+  timezone       = "Asia/Tokyo"
+  filters_string = "?Country=Japan"
+  include_links  = true
+
+  // the plan is owned by, and runs as, this user
+  sudo_as_user_id = looker_user.sales_manager.id
+
+  destination {
+    type    = "email"
+    address = "sales@example.com"
+    format  = "wysiwyg_pdf"
+    message = "Weekly sales report"
+  }
+
+  destination {
+    type    = "s3"
+    address = "s3://reports/sales"
+    format  = "csv"
+    parameters = jsonencode({
+      region        = "ap-northeast-1"
+      access_key_id = var.reports_access_key_id
+    })
+    secret_parameters = jsonencode({
+      secret_access_key = var.reports_secret_access_key
+    })
+  }
+}
+
+// a Look delivered when the orders datagroup is triggered
+resource "looker_scheduled_plan" "orders" {
+  name      = "Completed orders"
+  look_id   = looker_look.orders.id
+  datagroup = "orders_etl"
+
+  destination {
+    type    = "webhook"
+    address = "https://example.com/hooks/orders"
+    format  = "json_detail"
+  }
+}
@@ -0,0 +1,5 @@
+# user can be imported using the user ID
+terraform import looker_user.user <user_id>
+
+# or using the email address of the user, prefixed with "email:"
+terraform import looker_user.user email:someone@example.com
@@ -2,5 +2,15 @@ resource "looker_user" "user" {
   email                     = "user@email.com"
   first_name                = "Reporting"
   last_name                 = "API User"
+  locale                    = "en-US"
   send_setup_link_on_create = true
 }
+
+// disable the user instead of deleting it, after moving their personal content to a shared folder
+resource "looker_user" "employee" {
+  email                      = "employee@email.com"
+  first_name                 = "Jane"
+  last_name                  = "Doe"
+  deletion_mode              = "disable"
+  content_transfer_folder_id = looker_folder.archive.id
+}
@@ -0,0 +1,3 @@
+# user api credentials can be imported using the user ID and the credentials ID separated by a colon,
+# the client secret is not available after an import
+terraform import looker_user_api_credentials.integration <user_id>:<credentials_id>
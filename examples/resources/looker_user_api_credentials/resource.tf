@@ -0,0 +1,21 @@
+resource "looker_user_api_credentials" "integration" {
+  user_id = looker_service_account.integration.id
+
+  // change the value to rotate the key
+  rotation_trigger = {
+    rotated_at = "2024-01"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+output "client_id" {
+  value = looker_user_api_credentials.integration.client_id
+}
+
+output "client_secret" {
+  value     = looker_user_api_credentials.integration.client_secret
+  sensitive = true
+}
@@ -0,0 +1,2 @@
+# user role can be imported using the user ID and the role ID separated by a colon
+terraform import looker_user_role.user_role <user_id>:<role_id>
@@ -0,0 +1,4 @@
+resource "looker_user_role" "user_role" {
+  user_id = looker_user.user.id
+  role_id = looker_role.role.id
+}
@@ -0,0 +1,203 @@
+package fakelooker
+
+import (
+	"net/http"
+	"strings"
+)
+
+// writeOnlyConnectionFields are accepted on write but never returned by Looker.
+var writeOnlyConnectionFields = []string{"password", "certificate"}
+
+func (s *Server) registerConnections(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/connections", s.allConnections)
+	mux.HandleFunc("POST "+apiPrefix+"/connections", s.createConnection)
+	mux.HandleFunc("GET "+apiPrefix+"/connections/{name}", s.getConnection)
+	mux.HandleFunc("PATCH "+apiPrefix+"/connections/{name}", s.updateConnection)
+	mux.HandleFunc("DELETE "+apiPrefix+"/connections/{name}", s.deleteConnection)
+}
+
+func renderConnection(connection object) object {
+	out := copyObject(connection)
+	delete(out, "id")
+	for _, field := range writeOnlyConnectionFields {
+		delete(out, field)
+	}
+	return out
+}
+
+func (s *Server) allConnections(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	connections := []object{}
+	for _, connection := range s.connections.list() {
+		connections = append(connections, renderConnection(connection))
+	}
+	writeJSON(w, http.StatusOK, connections)
+}
+
+func (s *Server) createConnection(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	// Looker stores connection names in lower case.
+	name := strings.ToLower(stringValue(body, "name"))
+	if name == "" {
+		writeValidationError(w, "name", "missing", "This field is required.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.connections.get(name); ok {
+		writeValidationError(w, "name", "already_exists", "Connection with this name already exists")
+		return
+	}
+	connection := object{}
+	merge(connection, body)
+	connection["name"] = name
+	s.connections.insert(name, connection)
+	writeJSON(w, http.StatusOK, renderConnection(connection))
+}
+
+func (s *Server) getConnection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	connection, ok := s.connections.get(strings.ToLower(r.PathValue("name")))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderConnection(connection))
+}
+
+func (s *Server) updateConnection(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	connection, ok := s.connections.get(strings.ToLower(r.PathValue("name")))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(connection, body, "name")
+	writeJSON(w, http.StatusOK, renderConnection(connection))
+}
+
+func (s *Server) deleteConnection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.connections.delete(strings.ToLower(r.PathValue("name"))) {
+		writeNotFound(w)
+		return
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) registerLookmlModels(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/lookml_models", s.allLookmlModels)
+	mux.HandleFunc("POST "+apiPrefix+"/lookml_models", s.createLookmlModel)
+	mux.HandleFunc("GET "+apiPrefix+"/lookml_models/{name}", s.getLookmlModel)
+	mux.HandleFunc("PATCH "+apiPrefix+"/lookml_models/{name}", s.updateLookmlModel)
+	mux.HandleFunc("DELETE "+apiPrefix+"/lookml_models/{name}", s.deleteLookmlModel)
+}
+
+func renderLookmlModel(model object) object {
+	out := copyObject(model)
+	delete(out, "id")
+	delete(out, "internal")
+	out["has_content"] = false
+	return out
+}
+
+func (s *Server) allLookmlModels(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// built-in models, like System Activity, are only listed on request
+	includeInternal := r.URL.Query().Get("include_internal") == "true"
+	models := []object{}
+	for _, model := range s.lookmlModels.list() {
+		if model["internal"] == true && !includeInternal {
+			continue
+		}
+		models = append(models, renderLookmlModel(model))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, models))
+}
+
+func (s *Server) createLookmlModel(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	name := stringValue(body, "name")
+	if name == "" {
+		writeValidationError(w, "name", "missing", "This field is required.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lookmlModels.get(name); ok {
+		writeValidationError(w, "name", "already_exists", "Model with this name already exists")
+		return
+	}
+	model := object{}
+	merge(model, body)
+	s.lookmlModels.insert(name, model)
+	writeJSON(w, http.StatusOK, renderLookmlModel(model))
+}
+
+func (s *Server) getLookmlModel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	model, ok := s.lookmlModels.get(r.PathValue("name"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderLookmlModel(model))
+}
+
+func (s *Server) updateLookmlModel(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	model, ok := s.lookmlModels.get(r.PathValue("name"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(model, body, "name")
+	writeJSON(w, http.StatusOK, renderLookmlModel(model))
+}
+
+func (s *Server) deleteLookmlModel(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.lookmlModels.delete(r.PathValue("name")) {
+		writeNotFound(w)
+		return
+	}
+	writeNoContent(w)
+}
@@ -0,0 +1,353 @@
+package fakelooker
+
+import (
+	"net/http"
+	"strings"
+)
+
+func (s *Server) registerContent(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+apiPrefix+"/folders", s.createFolder)
+	mux.HandleFunc("GET "+apiPrefix+"/folders/{id}", s.getFolder)
+	mux.HandleFunc("PATCH "+apiPrefix+"/folders/{id}", s.updateFolder)
+	mux.HandleFunc("DELETE "+apiPrefix+"/folders/{id}", s.deleteFolder)
+	mux.HandleFunc("GET "+apiPrefix+"/folders/{id}/children", s.folderChildren)
+	mux.HandleFunc("GET "+apiPrefix+"/folders/{id}/looks", s.folderLooks)
+	mux.HandleFunc("GET "+apiPrefix+"/folders/{id}/dashboards", s.folderDashboards)
+
+	mux.HandleFunc("GET "+apiPrefix+"/content_metadata/{id}", s.getContentMetadata)
+	mux.HandleFunc("PATCH "+apiPrefix+"/content_metadata/{id}", s.updateContentMetadata)
+
+	mux.HandleFunc("GET "+apiPrefix+"/content_metadata_access", s.allContentMetadataAccesses)
+	mux.HandleFunc("POST "+apiPrefix+"/content_metadata_access", s.createContentMetadataAccess)
+	mux.HandleFunc("PUT "+apiPrefix+"/content_metadata_access/{id}", s.updateContentMetadataAccess)
+	mux.HandleFunc("DELETE "+apiPrefix+"/content_metadata_access/{id}", s.deleteContentMetadataAccess)
+}
+
+// newContentMetadata registers metadata for a piece of content nested under the
+// content with parentMetadataID. Must be called with s.mu held.
+func (s *Server) newContentMetadata(name, parentMetadataID string, attrs object) object {
+	meta := object{
+		"name":      name,
+		"parent_id": parentMetadataID,
+		"inherits":  true,
+	}
+	merge(meta, attrs)
+	return s.contentMetadata.insert("", meta)
+}
+
+// deleteContentMetadata removes metadata and every access grant on it. Must be called with s.mu held.
+func (s *Server) deleteContentMetadata(id string) {
+	s.contentMetadata.delete(id)
+	for _, access := range s.contentAccess.list() {
+		if stringValue(access, "content_metadata_id") == id {
+			s.contentAccess.delete(stringValue(access, "id"))
+		}
+	}
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := stringValue(body, "name")
+	if name == "" {
+		writeValidationError(w, "name", "missing", "This field is required.")
+		return
+	}
+	parent, ok := s.folders.get(stringValue(body, "parent_id"))
+	if !ok {
+		writeValidationError(w, "parent_id", "invalid", "Parent folder does not exist")
+		return
+	}
+
+	folder := object{
+		"name":        name,
+		"parent_id":   parent["id"],
+		"creator_id":  currentUserID(r),
+		"is_personal": false,
+	}
+	s.folders.insert("", folder)
+	meta := s.newContentMetadata(name, stringValue(parent, "content_metadata_id"), object{"folder_id": folder["id"]})
+	folder["content_metadata_id"] = meta["id"]
+	writeJSON(w, http.StatusOK, folder)
+}
+
+// createPersonalFolder creates the personal folder of a new user below the "Users" root folder.
+// Must be called with s.mu held.
+func (s *Server) createPersonalFolder(user object) {
+	if user["is_service_account"] == true {
+		return
+	}
+	parent, _ := s.folders.get(UsersFolderID)
+	name := strings.TrimSpace(stringValue(user, "first_name") + " " + stringValue(user, "last_name"))
+	folder := object{
+		"name":        name,
+		"parent_id":   UsersFolderID,
+		"creator_id":  user["id"],
+		"is_personal": true,
+	}
+	s.folders.insert("", folder)
+	meta := s.newContentMetadata(name, stringValue(parent, "content_metadata_id"), object{"folder_id": folder["id"]})
+	folder["content_metadata_id"] = meta["id"]
+	user["personal_folder_id"] = folder["id"]
+}
+
+func (s *Server) getFolder(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	folder, ok := s.folders.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, folder)
+}
+
+func (s *Server) updateFolder(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	folder, ok := s.folders.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if parentID, ok := body["parent_id"].(string); ok {
+		parent, exists := s.folders.get(parentID)
+		if !exists || parentID == stringValue(folder, "id") {
+			writeValidationError(w, "parent_id", "invalid", "Parent folder does not exist")
+			return
+		}
+		if meta, ok := s.contentMetadata.get(stringValue(folder, "content_metadata_id")); ok {
+			meta["parent_id"] = parent["content_metadata_id"]
+		}
+	}
+	merge(folder, body, "content_metadata_id", "creator_id", "is_personal")
+	writeJSON(w, http.StatusOK, folder)
+}
+
+func (s *Server) deleteFolder(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	folder, ok := s.folders.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if folder["parent_id"] == nil || folder["is_personal"] == true {
+		writeValidationError(w, "id", "invalid", "Root and personal folders cannot be deleted")
+		return
+	}
+	s.deleteFolderTree(id)
+	writeNoContent(w)
+}
+
+// deleteFolderTree removes a folder and everything nested below it. Must be called with s.mu held.
+func (s *Server) deleteFolderTree(id string) {
+	for _, child := range s.folders.list() {
+		if child["parent_id"] == id {
+			s.deleteFolderTree(stringValue(child, "id"))
+		}
+	}
+	for _, look := range s.looks.list() {
+		if look["folder_id"] == id {
+			s.deleteLook(stringValue(look, "id"))
+		}
+	}
+	for _, dashboard := range s.dashboards.list() {
+		if dashboard["folder_id"] == id {
+			s.deleteDashboard(stringValue(dashboard, "id"))
+		}
+	}
+	if folder, ok := s.folders.get(id); ok {
+		s.deleteContentMetadata(stringValue(folder, "content_metadata_id"))
+		s.folders.delete(id)
+	}
+}
+
+func (s *Server) folderChildren(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.folders.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	children := []object{}
+	for _, folder := range s.folders.list() {
+		if folder["parent_id"] == id {
+			children = append(children, folder)
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(r, children))
+}
+
+func (s *Server) folderLooks(w http.ResponseWriter, r *http.Request) {
+	s.writeFolderContent(w, r, s.looks)
+}
+
+func (s *Server) folderDashboards(w http.ResponseWriter, r *http.Request) {
+	s.writeFolderContent(w, r, s.dashboards)
+}
+
+// writeFolderContent writes the items of content stored in the folder of the request.
+func (s *Server) writeFolderContent(w http.ResponseWriter, r *http.Request, content *collection) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.folders.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	items := []object{}
+	for _, item := range content.list() {
+		if item["folder_id"] == id && item["deleted"] != true {
+			items = append(items, item)
+		}
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) getContentMetadata(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, ok := s.contentMetadata.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, meta)
+}
+
+func (s *Server) updateContentMetadata(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	meta, ok := s.contentMetadata.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if inherits, ok := body["inherits"]; ok {
+		meta["inherits"] = inherits
+	}
+	writeJSON(w, http.StatusOK, meta)
+}
+
+func (s *Server) allContentMetadataAccesses(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.URL.Query().Get("content_metadata_id")
+	if _, ok := s.contentMetadata.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	accesses := []object{}
+	for _, access := range s.contentAccess.list() {
+		if stringValue(access, "content_metadata_id") == id {
+			accesses = append(accesses, access)
+		}
+	}
+	writeJSON(w, http.StatusOK, accesses)
+}
+
+func (s *Server) createContentMetadataAccess(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.contentMetadata.get(stringValue(body, "content_metadata_id")); !ok {
+		writeNotFound(w)
+		return
+	}
+	if !validPermissionType(body["permission_type"]) {
+		writeValidationError(w, "permission_type", "invalid", "Permission type must be view or edit")
+		return
+	}
+	userID, groupID := stringValue(body, "user_id"), stringValue(body, "group_id")
+	switch {
+	case userID != "" && groupID != "", userID == "" && groupID == "":
+		writeValidationError(w, "user_id", "invalid", "Exactly one of user_id or group_id must be set")
+		return
+	case userID != "":
+		if _, ok := s.users.get(userID); !ok {
+			writeValidationError(w, "user_id", "invalid", "User does not exist")
+			return
+		}
+	default:
+		if _, ok := s.groups.get(groupID); !ok {
+			writeValidationError(w, "group_id", "invalid", "Group does not exist")
+			return
+		}
+	}
+
+	access := object{}
+	merge(access, body)
+	writeJSON(w, http.StatusOK, s.contentAccess.insert("", access))
+}
+
+func (s *Server) updateContentMetadataAccess(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	access, ok := s.contentAccess.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if permissionType, ok := body["permission_type"]; ok {
+		if !validPermissionType(permissionType) {
+			writeValidationError(w, "permission_type", "invalid", "Permission type must be view or edit")
+			return
+		}
+		access["permission_type"] = permissionType
+	}
+	writeJSON(w, http.StatusOK, access)
+}
+
+func (s *Server) deleteContentMetadataAccess(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.contentAccess.delete(r.PathValue("id")) {
+		writeNotFound(w)
+		return
+	}
+	writeNoContent(w)
+}
+
+func validPermissionType(v interface{}) bool {
+	return v == "view" || v == "edit"
+}
@@ -0,0 +1,307 @@
+package fakelooker
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// lookmlProperty matches a top-level property of the first dashboard of a LookML dashboard file.
+var lookmlProperty = regexp.MustCompile(`(?m)^\s*(?:- )?(dashboard|title|description|refresh):\s*(.*?)\s*$`)
+
+func (s *Server) registerDashboards(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+apiPrefix+"/dashboards", s.createDashboard)
+	mux.HandleFunc("POST "+apiPrefix+"/dashboards/lookml", s.importDashboardFromLookml)
+	mux.HandleFunc("GET "+apiPrefix+"/dashboards/{id}", s.getDashboard)
+	mux.HandleFunc("PATCH "+apiPrefix+"/dashboards/{id}", s.updateDashboard)
+	mux.HandleFunc("DELETE "+apiPrefix+"/dashboards/{id}", s.permanentlyDeleteDashboard)
+	mux.HandleFunc("GET "+apiPrefix+"/dashboards/{id}/dashboard_filters", s.getDashboardFilters)
+
+	mux.HandleFunc("POST "+apiPrefix+"/dashboard_filters", s.createDashboardFilter)
+	mux.HandleFunc("GET "+apiPrefix+"/dashboard_filters/{id}", s.getDashboardFilter)
+	mux.HandleFunc("PATCH "+apiPrefix+"/dashboard_filters/{id}", s.updateDashboardFilter)
+	mux.HandleFunc("DELETE "+apiPrefix+"/dashboard_filters/{id}", s.deleteDashboardFilter)
+}
+
+// renderDashboard builds the API representation of a dashboard, with its filters ordered by row.
+// Must be called with s.mu held.
+func (s *Server) renderDashboard(dashboard object) object {
+	out := copyObject(dashboard)
+	out["dashboard_filters"] = s.filtersOf(stringValue(dashboard, "id"))
+	return out
+}
+
+// filtersOf returns the filters of the dashboard ordered by row. Must be called with s.mu held.
+func (s *Server) filtersOf(dashboardID string) []object {
+	filters := []object{}
+	for _, filter := range s.dashboardFilter.list() {
+		if stringValue(filter, "dashboard_id") == dashboardID {
+			filters = append(filters, filter)
+		}
+	}
+	// insertion sort keeps the filters with the same row in creation order
+	for i := 1; i < len(filters); i++ {
+		for j := i; j > 0 && row(filters[j]) < row(filters[j-1]); j-- {
+			filters[j], filters[j-1] = filters[j-1], filters[j]
+		}
+	}
+	return filters
+}
+
+func row(filter object) float64 {
+	v, _ := filter["row"].(float64)
+	return v
+}
+
+// insertDashboard stores a new dashboard in the folder, with content metadata nested under the folder's.
+// Must be called with s.mu held.
+func (s *Server) insertDashboard(folder object, dashboard object, userID string) object {
+	defaults := object{
+		"description":      "",
+		"refresh_interval": nil,
+		"hidden":           false,
+		"deleted":          false,
+		"user_id":          userID,
+	}
+	merge(defaults, dashboard)
+	defaults["folder_id"] = folder["id"]
+	s.dashboards.insert("", defaults)
+	meta := s.newContentMetadata(stringValue(defaults, "title"), stringValue(folder, "content_metadata_id"), object{"dashboard_id": defaults["id"]})
+	defaults["content_metadata_id"] = meta["id"]
+	return defaults
+}
+
+// deleteDashboard removes a dashboard with its filters and content metadata. Must be called with s.mu held.
+func (s *Server) deleteDashboard(id string) {
+	dashboard, ok := s.dashboards.get(id)
+	if !ok {
+		return
+	}
+	for _, filter := range s.filtersOf(id) {
+		s.dashboardFilter.delete(stringValue(filter, "id"))
+	}
+	s.deleteContentMetadata(stringValue(dashboard, "content_metadata_id"))
+	s.dashboards.delete(id)
+}
+
+// activeDashboard returns the dashboard unless it doesn't exist or is in the trash. Must be called with s.mu held.
+func (s *Server) activeDashboard(id string) (object, bool) {
+	dashboard, ok := s.dashboards.get(id)
+	if !ok || dashboard["deleted"] == true {
+		return nil, false
+	}
+	return dashboard, true
+}
+
+func (s *Server) createDashboard(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stringValue(body, "title") == "" {
+		writeValidationError(w, "title", "missing", "This field is required.")
+		return
+	}
+	folder, ok := s.folders.get(stringValue(body, "folder_id"))
+	if !ok {
+		writeValidationError(w, "folder_id", "invalid", "Folder does not exist")
+		return
+	}
+
+	dashboard := object{}
+	merge(dashboard, body, "content_metadata_id", "user_id", "deleted", "dashboard_filters")
+	dashboard = s.insertDashboard(folder, dashboard, currentUserID(r))
+	writeJSON(w, http.StatusOK, s.renderDashboard(dashboard))
+}
+
+// importDashboardFromLookml creates a dashboard from the title, description and refresh of the LookML,
+// in the folder or in the personal folder of the caller.
+func (s *Server) importDashboardFromLookml(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dashboard := object{}
+	for _, match := range lookmlProperty.FindAllStringSubmatch(stringValue(body, "lookml"), -1) {
+		key, value := match[1], strings.Trim(match[2], `"'`)
+		if _, seen := dashboard[key]; seen {
+			continue
+		}
+		if key == "refresh" {
+			key = "refresh_interval"
+		}
+		dashboard[key] = value
+	}
+	if stringValue(dashboard, "dashboard") == "" || stringValue(dashboard, "title") == "" {
+		writeValidationError(w, "lookml", "invalid", "LookML must define a dashboard with a title")
+		return
+	}
+	delete(dashboard, "dashboard")
+
+	folderID := stringValue(body, "folder_id")
+	if folderID == "" {
+		if user, ok := s.users.get(currentUserID(r)); ok {
+			folderID = stringValue(user, "personal_folder_id")
+		}
+	}
+	folder, ok := s.folders.get(folderID)
+	if !ok {
+		writeValidationError(w, "folder_id", "invalid", "Folder does not exist")
+		return
+	}
+
+	dashboard = s.insertDashboard(folder, dashboard, currentUserID(r))
+	writeJSON(w, http.StatusOK, s.renderDashboard(dashboard))
+}
+
+func (s *Server) getDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// soft-deleted dashboards stay readable, with deleted set, until they are permanently deleted
+	dashboard, ok := s.dashboards.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderDashboard(dashboard))
+}
+
+func (s *Server) updateDashboard(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dashboard, ok := s.dashboards.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if title, ok := body["title"]; ok && title == "" {
+		writeValidationError(w, "title", "missing", "This field is required.")
+		return
+	}
+	if folderID, ok := body["folder_id"].(string); ok {
+		folder, exists := s.folders.get(folderID)
+		if !exists {
+			writeValidationError(w, "folder_id", "invalid", "Folder does not exist")
+			return
+		}
+		if meta, ok := s.contentMetadata.get(stringValue(dashboard, "content_metadata_id")); ok {
+			meta["parent_id"] = folder["content_metadata_id"]
+		}
+	}
+	merge(dashboard, body, "content_metadata_id", "user_id", "dashboard_filters")
+	writeJSON(w, http.StatusOK, s.renderDashboard(dashboard))
+}
+
+// permanentlyDeleteDashboard removes the dashboard, whether it is in the trash or not. Dashboards deleted
+// through the API skip the trash, only updates with deleted set move them there.
+func (s *Server) permanentlyDeleteDashboard(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.dashboards.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.deleteDashboard(id)
+	writeNoContent(w)
+}
+
+func (s *Server) getDashboardFilters(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.dashboards.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.filtersOf(id))
+}
+
+func (s *Server) createDashboardFilter(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.activeDashboard(stringValue(body, "dashboard_id")); !ok {
+		writeValidationError(w, "dashboard_id", "invalid", "Dashboard does not exist")
+		return
+	}
+	for _, field := range []string{"name", "title", "type"} {
+		if stringValue(body, field) == "" {
+			writeValidationError(w, field, "missing", "This field is required.")
+			return
+		}
+	}
+	for _, filter := range s.filtersOf(stringValue(body, "dashboard_id")) {
+		if stringValue(filter, "name") == stringValue(body, "name") {
+			writeValidationError(w, "name", "already_exists", "Dashboard already has a filter with this name")
+			return
+		}
+	}
+
+	filter := object{"allow_multiple_values": true, "required": false}
+	merge(filter, body)
+	writeJSON(w, http.StatusOK, s.dashboardFilter.insert("", filter))
+}
+
+func (s *Server) getDashboardFilter(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filter, ok := s.dashboardFilter.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, filter)
+}
+
+func (s *Server) updateDashboardFilter(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filter, ok := s.dashboardFilter.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(filter, body, "dashboard_id")
+	writeJSON(w, http.StatusOK, filter)
+}
+
+func (s *Server) deleteDashboardFilter(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dashboardFilter.delete(r.PathValue("id")) {
+		writeNotFound(w)
+		return
+	}
+	writeNoContent(w)
+}
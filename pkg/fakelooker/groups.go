@@ -0,0 +1,288 @@
+package fakelooker
+
+import (
+	"net/http"
+	"strings"
+)
+
+func (s *Server) registerGroups(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/groups", s.allGroups)
+	mux.HandleFunc("POST "+apiPrefix+"/groups", s.createGroup)
+	mux.HandleFunc("GET "+apiPrefix+"/groups/{id}", s.getGroup)
+	mux.HandleFunc("PATCH "+apiPrefix+"/groups/{id}", s.updateGroup)
+	mux.HandleFunc("DELETE "+apiPrefix+"/groups/{id}", s.deleteGroup)
+
+	mux.HandleFunc("GET "+apiPrefix+"/groups/{id}/users", s.allGroupUsers)
+	mux.HandleFunc("POST "+apiPrefix+"/groups/{id}/users", s.addGroupUser)
+	mux.HandleFunc("DELETE "+apiPrefix+"/groups/{id}/users/{user_id}", s.deleteGroupUser)
+
+	mux.HandleFunc("GET "+apiPrefix+"/groups/{id}/groups", s.allGroupGroups)
+	mux.HandleFunc("POST "+apiPrefix+"/groups/{id}/groups", s.addGroupGroup)
+	mux.HandleFunc("DELETE "+apiPrefix+"/groups/{id}/groups/{group_id}", s.deleteGroupGroup)
+
+	mux.HandleFunc("PATCH "+apiPrefix+"/groups/{id}/attribute_values/{attribute_id}", s.setGroupAttributeValue)
+	mux.HandleFunc("DELETE "+apiPrefix+"/groups/{id}/attribute_values/{attribute_id}", s.deleteGroupAttributeValue)
+}
+
+// renderGroup builds the API representation of a group. Must be called with s.mu held.
+func (s *Server) renderGroup(group object) object {
+	out := copyObject(group)
+	out["user_count"] = len(s.groupUsers[stringValue(group, "id")])
+	out["externally_managed"] = false
+	return out
+}
+
+func (s *Server) allGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groups := []object{}
+	for _, group := range s.groups.list() {
+		groups = append(groups, s.renderGroup(group))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, groups))
+}
+
+func (s *Server) createGroup(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := stringValue(body, "name")
+	if name == "" {
+		writeValidationError(w, "name", "missing", "This field is required.")
+		return
+	}
+	for _, group := range s.groups.list() {
+		if strings.EqualFold(stringValue(group, "name"), name) {
+			writeValidationError(w, "name", "already_exists", "Group with this name already exists")
+			return
+		}
+	}
+
+	group := object{}
+	merge(group, body)
+	s.groups.insert("", group)
+	writeJSON(w, http.StatusOK, s.renderGroup(group))
+}
+
+func (s *Server) getGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.groups.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderGroup(group))
+}
+
+func (s *Server) updateGroup(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	group, ok := s.groups.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(group, body)
+	writeJSON(w, http.StatusOK, s.renderGroup(group))
+}
+
+func (s *Server) deleteGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.groups.delete(id) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.groupUsers, id)
+	delete(s.groupGroups, id)
+	for parentID, children := range s.groupGroups {
+		s.groupGroups[parentID] = remove(children, id)
+	}
+	for roleID, groupIDs := range s.roleGroups {
+		s.roleGroups[roleID] = remove(groupIDs, id)
+	}
+	for key := range s.groupAttributeVals {
+		if strings.HasPrefix(key, id+":") {
+			delete(s.groupAttributeVals, key)
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) allGroupUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.groups.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	userIDs := append([]string{}, s.groupUsers[id]...)
+	sortIDs(userIDs)
+	users := []object{}
+	for _, userID := range userIDs {
+		if user, ok := s.users.get(userID); ok {
+			users = append(users, s.renderUser(user))
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(r, users))
+}
+
+func (s *Server) addGroupUser(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.groups.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	userID := stringValue(body, "user_id")
+	user, ok := s.users.get(userID)
+	if !ok {
+		writeValidationError(w, "user_id", "invalid", "User "+userID+" does not exist")
+		return
+	}
+	if !contains(s.groupUsers[id], userID) {
+		s.groupUsers[id] = append(s.groupUsers[id], userID)
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) deleteGroupUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, userID := r.PathValue("id"), r.PathValue("user_id")
+	if _, ok := s.groups.get(id); !ok || !contains(s.groupUsers[id], userID) {
+		writeNotFound(w)
+		return
+	}
+	s.groupUsers[id] = remove(s.groupUsers[id], userID)
+	writeNoContent(w)
+}
+
+func (s *Server) allGroupGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.groups.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	groupIDs := append([]string{}, s.groupGroups[id]...)
+	sortIDs(groupIDs)
+	groups := []object{}
+	for _, groupID := range groupIDs {
+		if group, ok := s.groups.get(groupID); ok {
+			groups = append(groups, s.renderGroup(group))
+		}
+	}
+	writeJSON(w, http.StatusOK, paginate(r, groups))
+}
+
+func (s *Server) addGroupGroup(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.groups.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	groupID := stringValue(body, "group_id")
+	group, ok := s.groups.get(groupID)
+	if !ok || groupID == id {
+		writeValidationError(w, "group_id", "invalid", "Group "+groupID+" cannot be included")
+		return
+	}
+	if !contains(s.groupGroups[id], groupID) {
+		s.groupGroups[id] = append(s.groupGroups[id], groupID)
+	}
+	writeJSON(w, http.StatusOK, s.renderGroup(group))
+}
+
+func (s *Server) deleteGroupGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, groupID := r.PathValue("id"), r.PathValue("group_id")
+	if _, ok := s.groups.get(id); !ok || !contains(s.groupGroups[id], groupID) {
+		writeNotFound(w)
+		return
+	}
+	s.groupGroups[id] = remove(s.groupGroups[id], groupID)
+	writeNoContent(w)
+}
+
+func (s *Server) setGroupAttributeValue(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groupID, attributeID := r.PathValue("id"), r.PathValue("attribute_id")
+	_, groupOK := s.groups.get(groupID)
+	_, attributeOK := s.userAttribute.get(attributeID)
+	if !groupOK || !attributeOK {
+		writeNotFound(w)
+		return
+	}
+
+	value := object{
+		"id":                groupID + "-" + attributeID,
+		"group_id":          groupID,
+		"user_attribute_id": attributeID,
+		"value":             body["value"],
+		"value_is_hidden":   false,
+		"rank":              1,
+	}
+	s.groupAttributeVals[groupID+":"+attributeID] = value
+	writeJSON(w, http.StatusOK, value)
+}
+
+func (s *Server) deleteGroupAttributeValue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := r.PathValue("id") + ":" + r.PathValue("attribute_id")
+	if _, ok := s.groupAttributeVals[key]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.groupAttributeVals, key)
+	writeNoContent(w)
+}
@@ -0,0 +1,181 @@
+package fakelooker
+
+import (
+	"net/http"
+)
+
+func (s *Server) registerLooks(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+apiPrefix+"/queries", s.createQuery)
+	mux.HandleFunc("GET "+apiPrefix+"/queries/{id}", s.getQuery)
+
+	mux.HandleFunc("POST "+apiPrefix+"/looks", s.createLook)
+	mux.HandleFunc("GET "+apiPrefix+"/looks/{id}", s.getLook)
+	mux.HandleFunc("PATCH "+apiPrefix+"/looks/{id}", s.updateLook)
+	mux.HandleFunc("DELETE "+apiPrefix+"/looks/{id}", s.permanentlyDeleteLook)
+}
+
+// renderLook builds the API representation of a look, with its query. Must be called with s.mu held.
+func (s *Server) renderLook(look object) object {
+	out := copyObject(look)
+	if query, ok := s.queries.get(stringValue(look, "query_id")); ok {
+		out["query"] = query
+	}
+	return out
+}
+
+// deleteLook removes a look with its content metadata. Must be called with s.mu held.
+func (s *Server) deleteLook(id string) {
+	look, ok := s.looks.get(id)
+	if !ok {
+		return
+	}
+	s.deleteContentMetadata(stringValue(look, "content_metadata_id"))
+	s.looks.delete(id)
+}
+
+// createQuery stores a query. Like in Looker, queries are immutable once created.
+func (s *Server) createQuery(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, field := range []string{"model", "view"} {
+		if stringValue(body, field) == "" {
+			writeValidationError(w, field, "missing", "This field is required.")
+			return
+		}
+	}
+
+	query := object{
+		"fields":         nil,
+		"filters":        nil,
+		"sorts":          nil,
+		"pivots":         nil,
+		"limit":          nil,
+		"dynamic_fields": nil,
+	}
+	merge(query, body, "slug")
+	s.queries.insert("", query)
+	query["slug"] = "q" + stringValue(query, "id")
+	writeJSON(w, http.StatusOK, query)
+}
+
+func (s *Server) getQuery(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query, ok := s.queries.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, query)
+}
+
+func (s *Server) createLook(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stringValue(body, "title") == "" {
+		writeValidationError(w, "title", "missing", "This field is required.")
+		return
+	}
+	folder, ok := s.folders.get(stringValue(body, "folder_id"))
+	if !ok {
+		writeValidationError(w, "folder_id", "invalid", "Folder does not exist")
+		return
+	}
+	if _, ok := s.queries.get(stringValue(body, "query_id")); !ok {
+		writeValidationError(w, "query_id", "invalid", "Query does not exist")
+		return
+	}
+
+	look := object{
+		"description":    "",
+		"is_run_on_load": false,
+		"public":         false,
+		"deleted":        false,
+		"user_id":        currentUserID(r),
+	}
+	merge(look, body, "content_metadata_id", "user_id", "deleted", "query")
+	s.looks.insert("", look)
+	meta := s.newContentMetadata(stringValue(look, "title"), stringValue(folder, "content_metadata_id"), object{"look_id": look["id"]})
+	look["content_metadata_id"] = meta["id"]
+	writeJSON(w, http.StatusOK, s.renderLook(look))
+}
+
+func (s *Server) getLook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// looks in the trash stay readable, with deleted set, until they are permanently deleted
+	look, ok := s.looks.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderLook(look))
+}
+
+// updateLook handles updates of looks, including moves to another folder and to or from the trash.
+func (s *Server) updateLook(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	look, ok := s.looks.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if title, ok := body["title"]; ok && title == "" {
+		writeValidationError(w, "title", "missing", "This field is required.")
+		return
+	}
+	if queryID, ok := body["query_id"].(string); ok {
+		if _, exists := s.queries.get(queryID); !exists {
+			writeValidationError(w, "query_id", "invalid", "Query does not exist")
+			return
+		}
+	}
+	if folderID, ok := body["folder_id"].(string); ok {
+		folder, exists := s.folders.get(folderID)
+		if !exists {
+			writeValidationError(w, "folder_id", "invalid", "Folder does not exist")
+			return
+		}
+		if meta, ok := s.contentMetadata.get(stringValue(look, "content_metadata_id")); ok {
+			meta["parent_id"] = folder["content_metadata_id"]
+		}
+	}
+	merge(look, body, "content_metadata_id", "user_id", "query")
+	writeJSON(w, http.StatusOK, s.renderLook(look))
+}
+
+// permanentlyDeleteLook removes the look, whether it is in the trash or not. Like dashboards,
+// looks deleted through the API skip the trash.
+func (s *Server) permanentlyDeleteLook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.looks.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.deleteLook(id)
+	writeNoContent(w)
+}
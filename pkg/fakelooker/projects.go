@@ -0,0 +1,357 @@
+package fakelooker
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const (
+	workspaceProduction = "production"
+	workspaceDev        = "dev"
+)
+
+// projectName matches the names Looker accepts for projects.
+var projectName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+var pullRequestModes = []string{"off", "links", "recommended", "required"}
+
+// gitServices are the Git services Looker detects from the remote URL of a project.
+var gitServices = []string{"github", "gitlab", "bitbucket"}
+
+func (s *Server) registerProjects(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/session", s.getSession)
+	mux.HandleFunc("PATCH "+apiPrefix+"/session", s.updateSession)
+
+	mux.HandleFunc("POST "+apiPrefix+"/projects", s.createProject)
+	mux.HandleFunc("GET "+apiPrefix+"/projects/{id}", s.getProject)
+	mux.HandleFunc("PATCH "+apiPrefix+"/projects/{id}", s.updateProject)
+	mux.HandleFunc("GET "+apiPrefix+"/projects/{id}/git/deploy_key", s.getGitDeployKey)
+	mux.HandleFunc("POST "+apiPrefix+"/projects/{id}/git/deploy_key", s.createGitDeployKey)
+	mux.HandleFunc("GET "+apiPrefix+"/projects/{id}/git_branch", s.getGitBranch)
+	mux.HandleFunc("PUT "+apiPrefix+"/projects/{id}/git_branch", s.updateGitBranch)
+	mux.HandleFunc("POST "+apiPrefix+"/projects/{id}/validate", s.validateProject)
+	mux.HandleFunc("POST "+apiPrefix+"/projects/{id}/deploy_ref_to_production", s.deployRefToProduction)
+}
+
+// AddLookMLError makes the validation of the project fail with a LookML error when ref is checked out,
+// in the dev branch of a user or in production. Severity is one of fatal, error, warning or info.
+func (s *Server) AddLookMLError(projectID, ref, severity, filePath, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := projectID + ":" + ref
+	s.lookmlErrors[key] = append(s.lookmlErrors[key], object{
+		"severity":    severity,
+		"kind":        "lookml",
+		"file_path":   filePath,
+		"line_number": 1,
+		"message":     message,
+	})
+}
+
+// workspace returns the workspace of the API session of the request. Must be called with s.mu held.
+func (s *Server) workspace(r *http.Request) string {
+	if workspace, ok := s.workspaces[currentToken(r)]; ok {
+		return workspace
+	}
+	return workspaceProduction
+}
+
+// requireDevWorkspace rejects the request unless its API session is in the dev workspace, as Looker does
+// for changes to projects. Must be called with s.mu held.
+func (s *Server) requireDevWorkspace(w http.ResponseWriter, r *http.Request) bool {
+	if s.workspace(r) != workspaceDev {
+		writeValidationError(w, "workspace_id", "invalid", "Projects can only be changed in the dev workspace")
+		return false
+	}
+	return true
+}
+
+// visibleProject returns the project unless the API session is in the production workspace
+// and the project was never deployed to production. Must be called with s.mu held.
+func (s *Server) visibleProject(r *http.Request, id string) (object, bool) {
+	project, ok := s.projects.get(id)
+	if !ok {
+		return nil, false
+	}
+	if s.workspace(r) == workspaceProduction && project["has_production_counterpart"] != true {
+		return nil, false
+	}
+	return project, true
+}
+
+func renderProject(project object) object {
+	out := copyObject(project)
+	delete(out, "git_password")
+	delete(out, "deploy_secret")
+	return out
+}
+
+func (s *Server) getSession(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, object{"workspace_id": s.workspace(r)})
+}
+
+func (s *Server) updateSession(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	workspace := stringValue(body, "workspace_id")
+	if workspace != workspaceProduction && workspace != workspaceDev {
+		writeValidationError(w, "workspace_id", "invalid", "Workspace must be production or dev")
+		return
+	}
+	s.workspaces[currentToken(r)] = workspace
+	writeJSON(w, http.StatusOK, object{"workspace_id": workspace})
+}
+
+func (s *Server) createProject(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.requireDevWorkspace(w, r) {
+		return
+	}
+	name := stringValue(body, "name")
+	if !projectName.MatchString(name) {
+		writeValidationError(w, "name", "invalid", "Project name can only contain alphanumerics, dashes and underscores")
+		return
+	}
+	if _, exists := s.projects.get(name); exists {
+		writeValidationError(w, "name", "already_exists", "Project already exists")
+		return
+	}
+	if _, ok := body["git_remote_url"]; ok {
+		writeValidationError(w, "git_remote_url", "invalid", "Git can only be configured by updating the project")
+		return
+	}
+
+	project := object{
+		"name":                       name,
+		"uses_git":                   false,
+		"git_remote_url":             nil,
+		"git_service_name":           nil,
+		"git_production_branch_name": "master",
+		"pull_request_mode":          "off",
+		"validation_required":        false,
+		"allow_warnings":             true,
+		"is_git_dev_locked":          false,
+		"has_production_counterpart": false,
+	}
+	if !applyProjectSettings(w, project, body) {
+		return
+	}
+	s.projects.insert(name, project)
+	writeJSON(w, http.StatusOK, renderProject(project))
+}
+
+// applyProjectSettings validates and applies the settings of the body to the project.
+func applyProjectSettings(w http.ResponseWriter, project, body object) bool {
+	if mode, ok := body["pull_request_mode"].(string); ok && !contains(pullRequestModes, mode) {
+		writeValidationError(w, "pull_request_mode", "invalid", "Pull request mode must be one of off, links, recommended or required")
+		return false
+	}
+	merge(project, body, "name", "uses_git", "is_git_dev_locked", "has_production_counterpart")
+	remoteURL := stringValue(project, "git_remote_url")
+	project["uses_git"] = remoteURL != ""
+	if stringValue(project, "git_service_name") == "" {
+		for _, service := range gitServices {
+			if strings.Contains(remoteURL, service+".") {
+				project["git_service_name"] = service
+			}
+		}
+	}
+	return true
+}
+
+func (s *Server) getProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.visibleProject(r, r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderProject(project))
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.requireDevWorkspace(w, r) {
+		return
+	}
+	project, ok := s.projects.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if !applyProjectSettings(w, project, body) {
+		return
+	}
+	writeJSON(w, http.StatusOK, renderProject(project))
+}
+
+// getGitDeployKey writes the public key of the project as plain text, like Looker does.
+func (s *Server) getGitDeployKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.requireDevWorkspace(w, r) {
+		return
+	}
+	key, ok := s.deployKeys[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeText(w, key)
+}
+
+// createGitDeployKey generates a new key pair for the project, replacing the previous one.
+func (s *Server) createGitDeployKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.requireDevWorkspace(w, r) {
+		return
+	}
+	id := r.PathValue("id")
+	if _, ok := s.projects.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	key := "ssh-rsa AAAAB3NzaC1yc2E" + randomHex(32) + " looker@" + id
+	s.deployKeys[id] = key
+	writeText(w, key)
+}
+
+// checkedOutBranch returns the branch the API session sees: the dev branch of the user in the dev workspace
+// and the production branch otherwise. Must be called with s.mu held.
+func (s *Server) checkedOutBranch(r *http.Request, project object) object {
+	id := stringValue(project, "name")
+	if s.workspace(r) == workspaceDev {
+		userID := currentUserID(r)
+		return object{"name": "dev-user-" + userID, "ref": s.devBranches[userID+":"+id], "readonly": false}
+	}
+	return object{"name": project["git_production_branch_name"], "ref": s.productionRefs[id], "readonly": true}
+}
+
+func (s *Server) getGitBranch(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.visibleProject(r, r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.checkedOutBranch(r, project))
+}
+
+// updateGitBranch resets the dev branch of the user to the ref of the body, like git reset --hard.
+func (s *Server) updateGitBranch(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.requireDevWorkspace(w, r) {
+		return
+	}
+	project, ok := s.projects.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if project["uses_git"] != true {
+		writeValidationError(w, "ref", "invalid", "Git must be configured for the project")
+		return
+	}
+	if ref := stringValue(body, "ref"); ref != "" {
+		s.devBranches[currentUserID(r)+":"+r.PathValue("id")] = ref
+	}
+	writeJSON(w, http.StatusOK, s.checkedOutBranch(r, project))
+}
+
+// validateProject returns the LookML errors registered with AddLookMLError for the checked out ref.
+func (s *Server) validateProject(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.visibleProject(r, r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	ref := stringValue(s.checkedOutBranch(r, project), "ref")
+	errs := []object{}
+	for _, e := range s.lookmlErrors[r.PathValue("id")+":"+ref] {
+		errs = append(errs, copyObject(e))
+	}
+	writeJSON(w, http.StatusOK, object{
+		"errors":               errs,
+		"project_digest":       randomHex(20),
+		"models_not_validated": []object{},
+		"computation_time":     0.1,
+	})
+}
+
+// deployRefToProduction checks out the branch or ref of the query in the production project,
+// creating the production project on the first deploy. Unlike changes to projects, deploys are
+// allowed in both workspaces.
+func (s *Server) deployRefToProduction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	project, ok := s.projects.get(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	branch, ref := r.URL.Query().Get("branch"), r.URL.Query().Get("ref")
+	if (branch == "") == (ref == "") {
+		writeError(w, http.StatusBadRequest, "Can only specify either a branch or a ref")
+		return
+	}
+	if project["uses_git"] != true {
+		writeValidationError(w, "project_id", "invalid", "Git must be configured to deploy the project")
+		return
+	}
+	if ref == "" {
+		ref = branch
+	}
+	project["has_production_counterpart"] = true
+	s.productionRefs[id] = ref
+	writeNoContent(w)
+}
+
+func writeText(w http.ResponseWriter, text string) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(text))
+}
@@ -0,0 +1,213 @@
+package fakelooker
+
+import "net/http"
+
+// Permissions are the permission symbols the fake instance knows, a subset of the ones of a real instance.
+var Permissions = []string{
+	"access_data",
+	"see_lookml_dashboards",
+	"see_looks",
+	"see_user_dashboards",
+	"explore",
+	"create_table_calculations",
+	"save_content",
+	"create_public_looks",
+	"download_with_limit",
+	"download_without_limit",
+	"schedule_look_emails",
+	"send_to_integration",
+	"see_sql",
+	"see_lookml",
+	"develop",
+	"deploy",
+	"use_sql_runner",
+	"manage_models",
+	"sudo",
+	"see_users",
+	"administer",
+}
+
+func (s *Server) registerRoles(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/permissions", s.allPermissions)
+
+	s.registerCollection(mux, "/permission_sets", s.permissionSets, "name")
+	s.registerCollection(mux, "/model_sets", s.modelSets, "name")
+
+	mux.HandleFunc("GET "+apiPrefix+"/roles", s.allRoles)
+	mux.HandleFunc("POST "+apiPrefix+"/roles", s.createRole)
+	mux.HandleFunc("GET "+apiPrefix+"/roles/{id}", s.getRole)
+	mux.HandleFunc("PATCH "+apiPrefix+"/roles/{id}", s.updateRole)
+	mux.HandleFunc("DELETE "+apiPrefix+"/roles/{id}", s.deleteRole)
+
+	mux.HandleFunc("GET "+apiPrefix+"/roles/{id}/groups", s.getRoleGroups)
+	mux.HandleFunc("PUT "+apiPrefix+"/roles/{id}/groups", s.setRoleGroups)
+}
+
+func (s *Server) allPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions := []object{}
+	for _, permission := range Permissions {
+		permissions = append(permissions, object{"permission": permission, "description": permission})
+	}
+	writeJSON(w, http.StatusOK, permissions)
+}
+
+// renderRole expands the permission and model sets a role points at. Must be called with s.mu held.
+func (s *Server) renderRole(role object) object {
+	out := copyObject(role)
+	if set, ok := s.permissionSets.get(stringValue(role, "permission_set_id")); ok {
+		out["permission_set"] = copyObject(set)
+	}
+	if set, ok := s.modelSets.get(stringValue(role, "model_set_id")); ok {
+		out["model_set"] = copyObject(set)
+	}
+	return out
+}
+
+func (s *Server) renderRoles(roleIDs []string) []object {
+	roles := []object{}
+	for _, roleID := range roleIDs {
+		if role, ok := s.roles.get(roleID); ok {
+			roles = append(roles, s.renderRole(role))
+		}
+	}
+	return roles
+}
+
+// validateRole reports whether the sets referenced by role exist. Must be called with s.mu held.
+func (s *Server) validateRole(w http.ResponseWriter, role object) bool {
+	if _, ok := s.permissionSets.get(stringValue(role, "permission_set_id")); !ok {
+		writeValidationError(w, "permission_set_id", "invalid", "Permission set does not exist")
+		return false
+	}
+	if _, ok := s.modelSets.get(stringValue(role, "model_set_id")); !ok {
+		writeValidationError(w, "model_set_id", "invalid", "Model set does not exist")
+		return false
+	}
+	return true
+}
+
+func (s *Server) allRoles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roles := []object{}
+	for _, role := range s.roles.list() {
+		roles = append(roles, s.renderRole(role))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, roles))
+}
+
+func (s *Server) createRole(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role := object{}
+	merge(role, body, "permission_set", "model_set")
+	if !s.validateRole(w, role) {
+		return
+	}
+	s.roles.insert("", role)
+	writeJSON(w, http.StatusOK, s.renderRole(role))
+}
+
+func (s *Server) getRole(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role, ok := s.roles.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderRole(role))
+}
+
+func (s *Server) updateRole(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role, ok := s.roles.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	updated := copyObject(role)
+	merge(updated, body, "permission_set", "model_set")
+	if !s.validateRole(w, updated) {
+		return
+	}
+	merge(role, updated)
+	writeJSON(w, http.StatusOK, s.renderRole(role))
+}
+
+func (s *Server) deleteRole(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.roles.delete(id) {
+		writeNotFound(w)
+		return
+	}
+	delete(s.roleGroups, id)
+	for userID, roleIDs := range s.userRoles {
+		s.userRoles[userID] = remove(roleIDs, id)
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) renderRoleGroups(roleID string) []object {
+	groups := []object{}
+	for _, groupID := range s.roleGroups[roleID] {
+		if group, ok := s.groups.get(groupID); ok {
+			groups = append(groups, s.renderGroup(group))
+		}
+	}
+	return groups
+}
+
+func (s *Server) getRoleGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.roles.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderRoleGroups(id))
+}
+
+func (s *Server) setRoleGroups(w http.ResponseWriter, r *http.Request) {
+	var groupIDs []string
+	if !decodeBody(w, r, &groupIDs) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.roles.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	for _, groupID := range groupIDs {
+		if _, ok := s.groups.get(groupID); !ok {
+			writeValidationError(w, "group_ids", "invalid", "Group "+groupID+" does not exist")
+			return
+		}
+	}
+	s.roleGroups[id] = append([]string{}, groupIDs...)
+	writeJSON(w, http.StatusOK, s.renderRoleGroups(id))
+}
@@ -0,0 +1,240 @@
+package fakelooker
+
+import (
+	"net/http"
+)
+
+// destinationTypes are the delivery types Looker supports for scheduled plans.
+var destinationTypes = []string{"email", "webhook", "s3", "sftp", "action_hub"}
+
+func (s *Server) registerScheduledPlans(mux *http.ServeMux) {
+	mux.HandleFunc("POST "+apiPrefix+"/scheduled_plans", s.createScheduledPlan)
+	mux.HandleFunc("GET "+apiPrefix+"/scheduled_plans/{id}", s.getScheduledPlan)
+	mux.HandleFunc("PATCH "+apiPrefix+"/scheduled_plans/{id}", s.updateScheduledPlan)
+	mux.HandleFunc("DELETE "+apiPrefix+"/scheduled_plans/{id}", s.deleteScheduledPlan)
+}
+
+// renderScheduledPlan builds the API representation of a scheduled plan, with its destinations without their
+// write-only secret parameters. Must be called with s.mu held.
+func (s *Server) renderScheduledPlan(plan object) object {
+	out := copyObject(plan)
+	destinations := []object{}
+	for _, destination := range s.destinationsOf(stringValue(plan, "id")) {
+		rendered := copyObject(destination)
+		delete(rendered, "secret_parameters")
+		destinations = append(destinations, rendered)
+	}
+	out["scheduled_plan_destination"] = destinations
+	return out
+}
+
+// destinationsOf returns the destinations of the plan in creation order. Must be called with s.mu held.
+func (s *Server) destinationsOf(planID string) []object {
+	destinations := []object{}
+	for _, destination := range s.planDestinations.list() {
+		if stringValue(destination, "scheduled_plan_id") == planID {
+			destinations = append(destinations, destination)
+		}
+	}
+	return destinations
+}
+
+// deleteScheduledPlanTree removes a plan with its destinations. Must be called with s.mu held.
+func (s *Server) deleteScheduledPlanTree(id string) {
+	for _, destination := range s.destinationsOf(id) {
+		s.planDestinations.delete(stringValue(destination, "id"))
+	}
+	s.scheduledPlans.delete(id)
+}
+
+// visibleScheduledPlan returns the plan if the caller may see it: the admin sees every plan, other users only their own.
+// Must be called with s.mu held.
+func (s *Server) visibleScheduledPlan(r *http.Request, id string) (object, bool) {
+	plan, ok := s.scheduledPlans.get(id)
+	if !ok {
+		return nil, false
+	}
+	if userID := currentUserID(r); userID != AdminUserID && stringValue(plan, "user_id") != userID {
+		return nil, false
+	}
+	return plan, true
+}
+
+// validateScheduledPlan checks the target, the trigger, the owner and the destinations of a plan.
+// Must be called with s.mu held.
+func (s *Server) validateScheduledPlan(w http.ResponseWriter, r *http.Request, plan object, destinations []object) bool {
+	if stringValue(plan, "name") == "" {
+		writeValidationError(w, "name", "missing", "This field is required.")
+		return false
+	}
+
+	targets := 0
+	if id := stringValue(plan, "look_id"); id != "" {
+		if look, ok := s.looks.get(id); !ok || look["deleted"] == true {
+			writeValidationError(w, "look_id", "invalid", "Look does not exist")
+			return false
+		}
+		targets++
+	}
+	if id := stringValue(plan, "dashboard_id"); id != "" {
+		if _, ok := s.activeDashboard(id); !ok {
+			writeValidationError(w, "dashboard_id", "invalid", "Dashboard does not exist")
+			return false
+		}
+		targets++
+	}
+	if stringValue(plan, "lookml_dashboard_id") != "" {
+		targets++
+	}
+	if targets != 1 {
+		writeValidationError(w, "look_id", "invalid", "Exactly one of look_id, dashboard_id or lookml_dashboard_id must be set")
+		return false
+	}
+
+	if (stringValue(plan, "crontab") == "") == (stringValue(plan, "datagroup") == "") {
+		writeValidationError(w, "crontab", "invalid", "Exactly one of crontab or datagroup must be set")
+		return false
+	}
+
+	userID := stringValue(plan, "user_id")
+	if _, ok := s.users.get(userID); !ok {
+		writeValidationError(w, "user_id", "invalid", "User does not exist")
+		return false
+	}
+	if caller := currentUserID(r); caller != AdminUserID && userID != caller {
+		writeError(w, http.StatusForbidden, "Only admins can schedule on behalf of other users")
+		return false
+	}
+
+	for _, destination := range destinations {
+		if !contains(destinationTypes, stringValue(destination, "type")) {
+			writeValidationError(w, "scheduled_plan_destination", "invalid", "Destination type must be one of email, webhook, s3, sftp or action_hub")
+			return false
+		}
+		if stringValue(destination, "format") == "" {
+			writeValidationError(w, "scheduled_plan_destination", "missing", "Destination format is required.")
+			return false
+		}
+		if stringValue(destination, "type") != "action_hub" && stringValue(destination, "address") == "" {
+			writeValidationError(w, "scheduled_plan_destination", "missing", "Destination address is required.")
+			return false
+		}
+	}
+	return true
+}
+
+// expandDestinations returns the destinations of the body with their defaults.
+func expandDestinations(body object) []object {
+	destinations := []object{}
+	items, _ := body["scheduled_plan_destination"].([]interface{})
+	for _, item := range items {
+		destination := object{
+			"apply_formatting": false,
+			"apply_vis":        false,
+			"looker_recipient": false,
+		}
+		if fields, ok := item.(object); ok {
+			merge(destination, fields, "scheduled_plan_id")
+		}
+		destinations = append(destinations, destination)
+	}
+	return destinations
+}
+
+// replaceDestinations replaces the destinations of the plan, as Looker does when a plan is updated with destinations.
+// Must be called with s.mu held.
+func (s *Server) replaceDestinations(planID string, destinations []object) {
+	for _, destination := range s.destinationsOf(planID) {
+		s.planDestinations.delete(stringValue(destination, "id"))
+	}
+	for _, destination := range destinations {
+		destination["scheduled_plan_id"] = planID
+		s.planDestinations.insert("", destination)
+	}
+}
+
+func (s *Server) createScheduledPlan(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan := object{
+		"user_id":          currentUserID(r),
+		"run_as_recipient": false,
+		"enabled":          true,
+		"include_links":    false,
+		"filters_string":   nil,
+		"crontab":          nil,
+		"datagroup":        nil,
+		"timezone":         "America/Los_Angeles",
+	}
+	merge(plan, body, "scheduled_plan_destination")
+	destinations := expandDestinations(body)
+	if !s.validateScheduledPlan(w, r, plan, destinations) {
+		return
+	}
+	s.scheduledPlans.insert("", plan)
+	s.replaceDestinations(stringValue(plan, "id"), destinations)
+	writeJSON(w, http.StatusOK, s.renderScheduledPlan(plan))
+}
+
+func (s *Server) getScheduledPlan(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, ok := s.visibleScheduledPlan(r, r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderScheduledPlan(plan))
+}
+
+func (s *Server) updateScheduledPlan(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, ok := s.visibleScheduledPlan(r, r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+
+	updated := copyObject(plan)
+	merge(updated, body, "scheduled_plan_destination")
+	destinations := s.destinationsOf(stringValue(plan, "id"))
+	_, replace := body["scheduled_plan_destination"]
+	if replace {
+		destinations = expandDestinations(body)
+	}
+	if !s.validateScheduledPlan(w, r, updated, destinations) {
+		return
+	}
+	merge(plan, updated)
+	if replace {
+		s.replaceDestinations(stringValue(plan, "id"), destinations)
+	}
+	writeJSON(w, http.StatusOK, s.renderScheduledPlan(plan))
+}
+
+func (s *Server) deleteScheduledPlan(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.visibleScheduledPlan(r, id); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.deleteScheduledPlanTree(id)
+	writeNoContent(w)
+}
@@ -0,0 +1,587 @@
+// Package fakelooker provides an in-process fake of the Looker API 4.0 for tests.
+//
+// The fake keeps all state in memory and implements the subset of endpoints used by
+// the provider: authentication, users, groups, roles, permission sets, model sets,
+// connections, folders, looks, dashboards, content metadata access, scheduled plans,
+// user attributes, LookML models, projects, production deploys and API sessions.
+// It aims to mirror the observable behaviour of a real instance (status codes, error
+// bodies, server-assigned IDs) closely enough for the acceptance tests to run offline.
+package fakelooker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// ClientID is the API3 client ID accepted by the fake /login endpoint.
+	ClientID = "fake-client-id"
+	// ClientSecret is the API3 client secret accepted by the fake /login endpoint.
+	ClientSecret = "fake-client-secret"
+
+	// AdminUserID is the ID of the seeded user that owns the API3 credentials.
+	AdminUserID = "1"
+	// SharedFolderID is the ID of the seeded "Shared" root folder.
+	SharedFolderID = "1"
+	// UsersFolderID is the ID of the seeded "Users" root folder holding the personal folders.
+	UsersFolderID = "2"
+	// AllUsersGroupID is the ID of the seeded "All Users" group.
+	AllUsersGroupID = "1"
+	// SystemActivityModel is the name of the seeded built-in System Activity model.
+	SystemActivityModel = "system__activity"
+
+	apiPrefix        = "/api/4.0"
+	documentationURL = "https://cloud.google.com/looker/docs/r/api/support"
+)
+
+type object = map[string]interface{}
+
+// Server is a fake Looker instance backed by an httptest.Server.
+type Server struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	tokens map[string]string // access token -> user ID
+
+	users             *collection
+	credentialsEmail  map[string]object // user ID -> credentials_email
+	credentialsAPI3   *collection
+	userRoles         map[string][]string // user ID -> role IDs
+	userAttributeVals map[string]object   // user ID + ":" + attribute ID -> value
+
+	groups             *collection
+	groupUsers         map[string][]string // group ID -> user IDs
+	groupGroups        map[string][]string // group ID -> included group IDs
+	groupAttributeVals map[string]object   // group ID + ":" + attribute ID -> value
+
+	roles          *collection
+	roleGroups     map[string][]string // role ID -> group IDs
+	permissionSets *collection
+	modelSets      *collection
+
+	connections   *collection
+	lookmlModels  *collection
+	userAttribute *collection
+
+	folders         *collection
+	queries         *collection
+	looks           *collection
+	dashboards      *collection
+	dashboardFilter *collection
+	contentMetadata *collection
+	contentAccess   *collection
+
+	scheduledPlans   *collection
+	planDestinations *collection
+
+	workspaces map[string]string // access token -> workspace of the API session
+	projects   *collection
+	deployKeys map[string]string // project ID -> public key
+
+	devBranches    map[string]string   // user ID + ":" + project ID -> ref of the dev branch
+	productionRefs map[string]string   // project ID -> ref deployed to production
+	lookmlErrors   map[string][]object // project ID + ":" + ref -> LookML errors
+}
+
+// NewServer starts a fake Looker instance. Callers should Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		tokens:             map[string]string{},
+		users:              newCollection(),
+		credentialsEmail:   map[string]object{},
+		credentialsAPI3:    newCollection(),
+		userRoles:          map[string][]string{},
+		userAttributeVals:  map[string]object{},
+		groups:             newCollection(),
+		groupUsers:         map[string][]string{},
+		groupGroups:        map[string][]string{},
+		groupAttributeVals: map[string]object{},
+		roles:              newCollection(),
+		roleGroups:         map[string][]string{},
+		permissionSets:     newCollection(),
+		modelSets:          newCollection(),
+		connections:        newCollection(),
+		lookmlModels:       newCollection(),
+		userAttribute:      newCollection(),
+		folders:            newCollection(),
+		queries:            newCollection(),
+		looks:              newCollection(),
+		dashboards:         newCollection(),
+		dashboardFilter:    newCollection(),
+		contentMetadata:    newCollection(),
+		contentAccess:      newCollection(),
+		scheduledPlans:     newCollection(),
+		planDestinations:   newCollection(),
+		workspaces:         map[string]string{},
+		projects:           newCollection(),
+		deployKeys:         map[string]string{},
+		devBranches:        map[string]string{},
+		productionRefs:     map[string]string{},
+		lookmlErrors:       map[string][]object{},
+	}
+	s.seed()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+apiPrefix+"/login", s.handleLogin)
+	mux.HandleFunc("POST "+apiPrefix+"/login/{user_id}", s.handleLoginUser)
+	s.registerUsers(mux)
+	s.registerGroups(mux)
+	s.registerRoles(mux)
+	s.registerConnections(mux)
+	s.registerLookmlModels(mux)
+	s.registerUserAttributes(mux)
+	s.registerContent(mux)
+	s.registerLooks(mux)
+	s.registerDashboards(mux)
+	s.registerScheduledPlans(mux)
+	s.registerProjects(mux)
+
+	s.Server = httptest.NewServer(s.authenticate(mux))
+	return s
+}
+
+func (s *Server) seed() {
+	s.users.insert(AdminUserID, object{
+		"first_name":  "Admin",
+		"last_name":   "User",
+		"is_disabled": false,
+	})
+	s.credentialsEmail[AdminUserID] = object{"email": "admin@example.com"}
+	s.groups.insert(AllUsersGroupID, object{"name": "All Users"})
+	s.lookmlModels.insert(SystemActivityModel, object{
+		"name":                     SystemActivityModel,
+		"label":                    "System Activity",
+		"project_name":             SystemActivityModel,
+		"unlimited_db_connections": false,
+		"internal":                 true,
+	})
+
+	sharedMeta := s.contentMetadata.insert("", object{
+		"name":      "Shared",
+		"inherits":  false,
+		"folder_id": SharedFolderID,
+	})
+	s.folders.insert(SharedFolderID, object{
+		"name":                "Shared",
+		"parent_id":           nil,
+		"content_metadata_id": sharedMeta["id"],
+		"is_shared_root":      true,
+	})
+
+	usersMeta := s.contentMetadata.insert("", object{
+		"name":      "Users",
+		"inherits":  false,
+		"folder_id": UsersFolderID,
+	})
+	s.folders.insert(UsersFolderID, object{
+		"name":                "Users",
+		"parent_id":           nil,
+		"content_metadata_id": usersMeta["id"],
+		"is_users_root":       true,
+	})
+	s.createPersonalFolder(s.users.items[AdminUserID])
+}
+
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "Bad request")
+		return
+	}
+
+	s.mu.Lock()
+	userID, ok := s.login(r.Form.Get("client_id"), r.Form.Get("client_secret"))
+	if !ok {
+		s.mu.Unlock()
+		writeError(w, http.StatusNotFound, "Not found")
+		return
+	}
+	token := s.issueToken(userID)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// login returns the ID of the user owning the API3 credentials. Must be called with s.mu held.
+func (s *Server) login(clientID, clientSecret string) (string, bool) {
+	if clientID == ClientID && clientSecret == ClientSecret {
+		return AdminUserID, true
+	}
+	for _, creds := range s.credentialsAPI3.list() {
+		if stringValue(creds, "client_id") == clientID && stringValue(creds, "client_secret") == clientSecret &&
+			creds["is_disabled"] != true {
+			return stringValue(creds, "user_id"), true
+		}
+	}
+	return "", false
+}
+
+// handleLoginUser issues a sudo token for another user. Only the admin may sudo, and like on instances
+// without the license feature for it, non-associative sessions are refused.
+func (s *Server) handleLoginUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if currentUserID(r) != AdminUserID {
+		writeError(w, http.StatusForbidden, "Not authorized")
+		return
+	}
+	if r.URL.Query().Get("associative") == "false" {
+		writeError(w, http.StatusForbidden, "Non-associative sudo sessions are not enabled on this instance")
+		return
+	}
+	userID := r.PathValue("user_id")
+	if _, ok := s.users.get(userID); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, object{
+		"access_token": s.issueToken(userID),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// IssueToken returns an access token for userID, as handed out by a token broker
+// to clients that never see API credentials.
+func (s *Server) IssueToken(userID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.issueToken(userID)
+}
+
+// issueToken must be called with s.mu held.
+func (s *Server) issueToken(userID string) string {
+	token := randomHex(20)
+	s.tokens[token] = userID
+	return token
+}
+
+// randomHex returns n random bytes encoded as hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// authenticate rejects every request except /login that does not carry a valid token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == apiPrefix+"/login" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		auth := r.Header.Get("Authorization")
+		token := ""
+		for _, prefix := range []string{"Bearer ", "token "} {
+			if strings.HasPrefix(auth, prefix) {
+				token = strings.TrimPrefix(auth, prefix)
+			}
+		}
+
+		s.mu.Lock()
+		userID, ok := s.tokens[token]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "Requires authentication.")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), userIDKey{}, userID)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, tokenKey{}, token)))
+	})
+}
+
+// userIDKey is the request context key holding the ID of the user the request is authenticated as.
+type userIDKey struct{}
+
+// tokenKey is the request context key holding the access token of the request, which identifies its API session.
+type tokenKey struct{}
+
+// currentToken returns the access token the request is authenticated with.
+func currentToken(r *http.Request) string {
+	token, _ := r.Context().Value(tokenKey{}).(string)
+	return token
+}
+
+// currentUserID returns the ID of the user the request is authenticated as.
+func currentUserID(r *http.Request) string {
+	userID, _ := r.Context().Value(userIDKey{}).(string)
+	return userID
+}
+
+// collection stores objects keyed by ID and hands out sequential numeric IDs.
+type collection struct {
+	nextID int
+	items  map[string]object
+}
+
+func newCollection() *collection {
+	return &collection{nextID: 1, items: map[string]object{}}
+}
+
+// insert stores obj under id, or under the next free numeric ID when id is empty.
+func (c *collection) insert(id string, obj object) object {
+	if id == "" {
+		for {
+			id = strconv.Itoa(c.nextID)
+			c.nextID++
+			if _, exists := c.items[id]; !exists {
+				break
+			}
+		}
+	}
+	obj["id"] = id
+	c.items[id] = obj
+	return obj
+}
+
+func (c *collection) get(id string) (object, bool) {
+	obj, ok := c.items[id]
+	return obj, ok
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.items[id]; !ok {
+		return false
+	}
+	delete(c.items, id)
+	return true
+}
+
+// list returns the stored objects ordered by ID, numerically where possible.
+func (c *collection) list() []object {
+	ids := make([]string, 0, len(c.items))
+	for id := range c.items {
+		ids = append(ids, id)
+	}
+	sortIDs(ids)
+
+	objs := make([]object, 0, len(ids))
+	for _, id := range ids {
+		objs = append(objs, c.items[id])
+	}
+	return objs
+}
+
+func sortIDs(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, errA := strconv.Atoi(ids[i])
+		b, errB := strconv.Atoi(ids[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return ids[i] < ids[j]
+	})
+}
+
+// merge applies a PATCH-style body onto obj, ignoring read-only keys.
+func merge(obj, body object, readOnly ...string) {
+	for k, v := range body {
+		if k == "id" || contains(readOnly, k) {
+			continue
+		}
+		obj[k] = v
+	}
+}
+
+func copyObject(obj object) object {
+	c := make(object, len(obj))
+	for k, v := range obj {
+		c[k] = v
+	}
+	return c
+}
+
+func contains(slice []string, value string) bool {
+	for _, v := range slice {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(slice []string, value string) []string {
+	out := slice[:0]
+	for _, v := range slice {
+		if v != value {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func stringValue(obj object, key string) string {
+	if v, ok := obj[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+// paginate applies the limit/offset and page/per_page query parameters to objs.
+func paginate(r *http.Request, objs []object) []object {
+	q := r.URL.Query()
+	offset, limit := 0, len(objs)
+
+	if v, err := strconv.Atoi(q.Get("per_page")); err == nil && v > 0 {
+		limit = v
+		if page, err := strconv.Atoi(q.Get("page")); err == nil && page > 1 {
+			offset = (page - 1) * v
+		}
+	}
+	if v, err := strconv.Atoi(q.Get("limit")); err == nil && v > 0 {
+		limit = v
+	}
+	if v, err := strconv.Atoi(q.Get("offset")); err == nil && v > 0 {
+		offset = v
+	}
+
+	if offset >= len(objs) {
+		return []object{}
+	}
+	end := offset + limit
+	if end > len(objs) {
+		end = len(objs)
+	}
+	return objs[offset:end]
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Looker could not parse the request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, object{
+		"message":           message,
+		"documentation_url": documentationURL,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "Not found")
+}
+
+// writeValidationError mimics the 422 body Looker returns for invalid input.
+func writeValidationError(w http.ResponseWriter, field, code, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, object{
+		"message": "Validation Failed",
+		"errors": []object{{
+			"field":             field,
+			"code":              code,
+			"message":           message,
+			"documentation_url": documentationURL,
+		}},
+		"documentation_url": documentationURL,
+	})
+}
+
+// registerCollection wires plain create/read/update/delete endpoints for c under path.
+// Objects are stored as sent, so it suits resources without server-side derived fields.
+func (s *Server) registerCollection(mux *http.ServeMux, path string, c *collection, required ...string) {
+	mux.HandleFunc("GET "+apiPrefix+path, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, paginate(r, c.list()))
+	})
+	mux.HandleFunc("POST "+apiPrefix+path, func(w http.ResponseWriter, r *http.Request) {
+		var body object
+		if !decodeBody(w, r, &body) {
+			return
+		}
+		for _, field := range required {
+			if body[field] == nil || body[field] == "" {
+				writeValidationError(w, field, "missing", "This field is required.")
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj := object{}
+		merge(obj, body)
+		writeJSON(w, http.StatusOK, c.insert("", obj))
+	})
+	mux.HandleFunc("GET "+apiPrefix+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj, ok := c.get(r.PathValue("id"))
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		writeJSON(w, http.StatusOK, obj)
+	})
+	mux.HandleFunc("PATCH "+apiPrefix+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var body object
+		if !decodeBody(w, r, &body) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		obj, ok := c.get(r.PathValue("id"))
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		merge(obj, body)
+		writeJSON(w, http.StatusOK, obj)
+	})
+	mux.HandleFunc("DELETE "+apiPrefix+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !c.delete(r.PathValue("id")) {
+			writeNotFound(w)
+			return
+		}
+		writeNoContent(w)
+	})
+}
+
+// matchesSearch reports whether value matches a Looker search filter, a case-insensitive SQL LIKE pattern.
+func matchesSearch(pattern, value string) bool {
+	var expr strings.Builder
+	expr.WriteString("(?i)^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String()).MatchString(value)
+}
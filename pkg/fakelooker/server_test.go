@@ -0,0 +1,129 @@
+package fakelooker
+
+import (
+	"testing"
+
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, clientID, clientSecret string) *apiclient.LookerSDK {
+	t.Helper()
+
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	return apiclient.NewLookerSDK(rtl.NewAuthSession(rtl.ApiSettings{
+		BaseUrl:      srv.URL,
+		ClientId:     clientID,
+		ClientSecret: clientSecret,
+		ApiVersion:   "4.0",
+		VerifySsl:    true,
+	}))
+}
+
+func TestServer_Login(t *testing.T) {
+	tests := map[string]struct {
+		clientSecret string
+		wantErr      bool
+	}{
+		"valid credentials": {
+			clientSecret: ClientSecret,
+			wantErr:      false,
+		},
+		"invalid credentials": {
+			clientSecret: "wrong",
+			wantErr:      true,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			client := newTestClient(t, ClientID, tt.clientSecret)
+			_, err := client.User(AdminUserID, "", nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestServer_UserLifecycle(t *testing.T) {
+	a := assert.New(t)
+	client := newTestClient(t, ClientID, ClientSecret)
+
+	firstName, lastName, email := "Jane", "Doe", "jane@example.com"
+	user, err := client.CreateUser(apiclient.WriteUser{FirstName: &firstName, LastName: &lastName}, "", nil)
+	require.NoError(t, err)
+	_, err = client.CreateUserCredentialsEmail(*user.Id, apiclient.WriteCredentialsEmail{Email: &email}, "", nil)
+	require.NoError(t, err)
+
+	got, err := client.User(*user.Id, "", nil)
+	require.NoError(t, err)
+	a.Equal(email, *got.Email)
+	a.Equal("Jane Doe", *got.DisplayName)
+
+	// email addresses are unique across users
+	other, err := client.CreateUser(apiclient.WriteUser{}, "", nil)
+	require.NoError(t, err)
+	_, err = client.CreateUserCredentialsEmail(*other.Id, apiclient.WriteCredentialsEmail{Email: &email}, "", nil)
+	a.ErrorContains(err, "422")
+
+	_, err = client.DeleteUser(*user.Id, nil)
+	require.NoError(t, err)
+	_, err = client.User(*user.Id, "", nil)
+	a.ErrorContains(err, "404")
+}
+
+func TestServer_GroupMembershipPaging(t *testing.T) {
+	a := assert.New(t)
+	client := newTestClient(t, ClientID, ClientSecret)
+
+	name := "paged"
+	group, err := client.CreateGroup(apiclient.WriteGroup{Name: &name}, "", nil)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		user, err := client.CreateUser(apiclient.WriteUser{}, "", nil)
+		require.NoError(t, err)
+		_, err = client.AddGroupUser(*group.Id, apiclient.GroupIdForGroupUserInclusion{UserId: user.Id}, nil)
+		require.NoError(t, err)
+	}
+
+	limit, offset := int64(2), int64(4)
+	users, err := client.AllGroupUsers(apiclient.RequestAllGroupUsers{GroupId: *group.Id, Limit: &limit, Offset: &offset}, nil)
+	require.NoError(t, err)
+	a.Len(users, 1)
+}
+
+func TestServer_FolderAccess(t *testing.T) {
+	a := assert.New(t)
+	client := newTestClient(t, ClientID, ClientSecret)
+
+	folder, err := client.CreateFolder(apiclient.CreateFolder{Name: "reports", ParentId: SharedFolderID}, nil)
+	require.NoError(t, err)
+	require.NotNil(t, folder.ContentMetadataId)
+
+	permission := apiclient.PermissionType_View
+	groupID := AllUsersGroupID
+	access, err := client.CreateContentMetadataAccess(apiclient.ContentMetaGroupUser{
+		ContentMetadataId: folder.ContentMetadataId,
+		PermissionType:    &permission,
+		GroupId:           &groupID,
+	}, false, nil)
+	require.NoError(t, err)
+
+	accesses, err := client.AllContentMetadataAccesses(*folder.ContentMetadataId, "", nil)
+	require.NoError(t, err)
+	a.Len(accesses, 1)
+	a.Equal(*access.Id, *accesses[0].Id)
+
+	_, err = client.DeleteFolder(*folder.Id, nil)
+	require.NoError(t, err)
+	_, err = client.AllContentMetadataAccesses(*folder.ContentMetadataId, "", nil)
+	a.ErrorContains(err, "404")
+}
@@ -0,0 +1,125 @@
+package fakelooker
+
+import (
+	"net/http"
+	"strings"
+)
+
+func (s *Server) registerUserAttributes(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/user_attributes", s.allUserAttributes)
+	mux.HandleFunc("POST "+apiPrefix+"/user_attributes", s.createUserAttribute)
+	mux.HandleFunc("GET "+apiPrefix+"/user_attributes/{id}", s.getUserAttribute)
+	mux.HandleFunc("PATCH "+apiPrefix+"/user_attributes/{id}", s.updateUserAttribute)
+	mux.HandleFunc("DELETE "+apiPrefix+"/user_attributes/{id}", s.deleteUserAttribute)
+
+	mux.HandleFunc("GET "+apiPrefix+"/user_attributes/{id}/group_values", s.allUserAttributeGroupValues)
+}
+
+func (s *Server) allUserAttributes(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, s.userAttribute.list())
+}
+
+func (s *Server) createUserAttribute(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	for _, field := range []string{"name", "label", "type"} {
+		if stringValue(body, field) == "" {
+			writeValidationError(w, field, "missing", "This field is required.")
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attribute := object{
+		"is_system":       false,
+		"is_permanent":    false,
+		"value_is_hidden": false,
+		"user_can_view":   true,
+		"user_can_edit":   false,
+	}
+	merge(attribute, body, "is_system", "is_permanent")
+	s.userAttribute.insert("", attribute)
+	writeJSON(w, http.StatusOK, attribute)
+}
+
+func (s *Server) getUserAttribute(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attribute, ok := s.userAttribute.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, attribute)
+}
+
+func (s *Server) updateUserAttribute(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attribute, ok := s.userAttribute.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	// The hidden value domain allowlist cannot be edited once set.
+	if attribute["hidden_value_domain_whitelist"] != nil {
+		delete(body, "hidden_value_domain_whitelist")
+	}
+	merge(attribute, body, "is_system", "is_permanent")
+	writeJSON(w, http.StatusOK, attribute)
+}
+
+func (s *Server) deleteUserAttribute(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.userAttribute.delete(id) {
+		writeNotFound(w)
+		return
+	}
+	for key := range s.userAttributeVals {
+		if strings.HasSuffix(key, ":"+id) {
+			delete(s.userAttributeVals, key)
+		}
+	}
+	for key := range s.groupAttributeVals {
+		if strings.HasSuffix(key, ":"+id) {
+			delete(s.groupAttributeVals, key)
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) allUserAttributeGroupValues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.userAttribute.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	values := []object{}
+	for _, group := range s.groups.list() {
+		if value, ok := s.groupAttributeVals[stringValue(group, "id")+":"+id]; ok {
+			values = append(values, copyObject(value))
+		}
+	}
+	writeJSON(w, http.StatusOK, values)
+}
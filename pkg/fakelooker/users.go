@@ -0,0 +1,592 @@
+package fakelooker
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+func (s *Server) registerUsers(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+apiPrefix+"/user", s.me)
+	mux.HandleFunc("GET "+apiPrefix+"/users", s.allUsers)
+	mux.HandleFunc("GET "+apiPrefix+"/users/search", s.searchUsers)
+	mux.HandleFunc("POST "+apiPrefix+"/users", s.createUser)
+	mux.HandleFunc("GET "+apiPrefix+"/users/{id}", s.getUser)
+	mux.HandleFunc("PATCH "+apiPrefix+"/users/{id}", s.updateUser)
+	mux.HandleFunc("DELETE "+apiPrefix+"/users/{id}", s.deleteUser)
+
+	mux.HandleFunc("POST "+apiPrefix+"/users/{id}/credentials_email", s.createCredentialsEmail)
+	// PATCH and DELETE /users/{id}/credentials_email overlap with /users/service_accounts/{id} as
+	// ServeMux patterns, so both are routed through patchUserSubresource and deleteUserSubresource.
+	mux.HandleFunc("PATCH "+apiPrefix+"/users/{id}/{sub}", s.patchUserSubresource)
+	mux.HandleFunc("DELETE "+apiPrefix+"/users/{id}/{sub}", s.deleteUserSubresource)
+	mux.HandleFunc("POST "+apiPrefix+"/users/{id}/credentials_email/send_password_reset", s.sendPasswordReset)
+
+	mux.HandleFunc("GET "+apiPrefix+"/users/{id}/credentials_api3", s.allCredentialsAPI3)
+	mux.HandleFunc("POST "+apiPrefix+"/users/{id}/credentials_api3", s.createCredentialsAPI3)
+	mux.HandleFunc("GET "+apiPrefix+"/users/{id}/credentials_api3/{credentials_api3_id}", s.getCredentialsAPI3)
+	mux.HandleFunc("DELETE "+apiPrefix+"/users/{id}/credentials_api3/{credentials_api3_id}", s.deleteCredentialsAPI3)
+
+	mux.HandleFunc("GET "+apiPrefix+"/users/{id}/roles", s.getUserRoles)
+	mux.HandleFunc("PUT "+apiPrefix+"/users/{id}/roles", s.setUserRoles)
+
+	mux.HandleFunc("GET "+apiPrefix+"/users/{id}/attribute_values", s.getUserAttributeValues)
+	mux.HandleFunc("PATCH "+apiPrefix+"/users/{id}/attribute_values/{attribute_id}", s.setUserAttributeValue)
+	mux.HandleFunc("DELETE "+apiPrefix+"/users/{id}/attribute_values/{attribute_id}", s.deleteUserAttributeValue)
+
+	mux.HandleFunc("POST "+apiPrefix+"/users/service_accounts", s.createServiceAccount)
+}
+
+// renderUser builds the API representation of a user. Must be called with s.mu held.
+func (s *Server) renderUser(user object) object {
+	id := stringValue(user, "id")
+	out := copyObject(user)
+
+	if creds, ok := s.credentialsEmail[id]; ok {
+		out["credentials_email"] = copyObject(creds)
+		out["email"] = creds["email"]
+	} else {
+		out["credentials_email"] = nil
+		out["email"] = nil
+	}
+
+	first, last := stringValue(user, "first_name"), stringValue(user, "last_name")
+	if first != "" && last != "" {
+		out["display_name"] = first + " " + last
+	}
+
+	groupIDs := []string{}
+	for _, group := range s.groups.list() {
+		groupID := stringValue(group, "id")
+		if contains(s.groupUsers[groupID], id) {
+			groupIDs = append(groupIDs, groupID)
+		}
+	}
+	out["group_ids"] = groupIDs
+
+	roleIDs := append([]string{}, s.userRoles[id]...)
+	out["role_ids"] = roleIDs
+
+	return out
+}
+
+func (s *Server) emailTaken(email, exceptUserID string) bool {
+	for userID, creds := range s.credentialsEmail {
+		if userID != exceptUserID && strings.EqualFold(stringValue(creds, "email"), email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) allUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := splitDelim(r.URL.Query().Get("ids"))
+	users := []object{}
+	for _, user := range s.users.list() {
+		if len(ids) > 0 && !contains(ids, stringValue(user, "id")) {
+			continue
+		}
+		users = append(users, s.renderUser(user))
+	}
+	writeJSON(w, http.StatusOK, paginate(r, users))
+}
+
+// searchUsers supports the email filter, which like all Looker search filters is case-insensitive
+// and treats % and _ as wildcards.
+func (s *Server) searchUsers(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	query := r.URL.Query()
+	email, id, groupID := query.Get("email"), query.Get("id"), query.Get("group_id")
+	users := []object{}
+	for _, user := range s.users.list() {
+		rendered := s.renderUser(user)
+		if email != "" && !matchesSearch(email, stringValue(rendered, "email")) {
+			continue
+		}
+		if id != "" && stringValue(user, "id") != id {
+			continue
+		}
+		// group_id matches the direct members of the group
+		if groupID != "" && !contains(s.groupUsers[groupID], stringValue(user, "id")) {
+			continue
+		}
+		users = append(users, rendered)
+	}
+	writeJSON(w, http.StatusOK, paginate(r, users))
+}
+
+func (s *Server) createUser(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user := object{"is_disabled": false, "models_dir_validated": false, "presumed_looker_employee": false}
+	merge(user, body, "email", "credentials_email", "group_ids", "role_ids", "personal_folder_id", "presumed_looker_employee")
+	s.users.insert("", user)
+	s.createPersonalFolder(user)
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) me(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users.get(currentUserID(r))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) updateUser(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(user, body, "email", "credentials_email", "group_ids", "role_ids", "personal_folder_id", "presumed_looker_employee")
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) deleteUser(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if !s.users.delete(id) {
+		writeNotFound(w)
+		return
+	}
+	s.forgetUser(id)
+	writeNoContent(w)
+}
+
+// forgetUser drops every association of a deleted user, including their personal folder and scheduled plans.
+// Must be called with s.mu held.
+func (s *Server) forgetUser(id string) {
+	for _, folder := range s.folders.list() {
+		if folder["is_personal"] == true && stringValue(folder, "creator_id") == id {
+			s.deleteFolderTree(stringValue(folder, "id"))
+		}
+	}
+	delete(s.credentialsEmail, id)
+	for _, creds := range s.credentialsAPI3.list() {
+		if stringValue(creds, "user_id") == id {
+			s.credentialsAPI3.delete(stringValue(creds, "id"))
+		}
+	}
+	for _, plan := range s.scheduledPlans.list() {
+		if stringValue(plan, "user_id") == id {
+			s.deleteScheduledPlanTree(stringValue(plan, "id"))
+		}
+	}
+	delete(s.userRoles, id)
+	for groupID, members := range s.groupUsers {
+		s.groupUsers[groupID] = remove(members, id)
+	}
+	for key := range s.userAttributeVals {
+		if strings.HasPrefix(key, id+":") {
+			delete(s.userAttributeVals, key)
+		}
+	}
+	for token, userID := range s.tokens {
+		if userID == id {
+			delete(s.tokens, token)
+		}
+	}
+}
+
+func (s *Server) createCredentialsEmail(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	if _, ok := s.credentialsEmail[id]; ok {
+		writeValidationError(w, "credentials_email", "already_exists", "User already has email credentials")
+		return
+	}
+	email := stringValue(body, "email")
+	if email == "" {
+		writeValidationError(w, "email", "missing", "This field is required.")
+		return
+	}
+	if s.emailTaken(email, id) {
+		writeValidationError(w, "email", "duplicate", "Email address is already in use")
+		return
+	}
+
+	creds := object{"email": email, "is_disabled": false}
+	s.credentialsEmail[id] = creds
+	writeJSON(w, http.StatusOK, creds)
+}
+
+func (s *Server) deleteCredentialsEmail(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.credentialsEmail[id]; !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.credentialsEmail, id)
+	writeNoContent(w)
+}
+
+func (s *Server) patchUserSubresource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("id") == "service_accounts":
+		s.updateServiceAccount(w, r, r.PathValue("sub"))
+	case r.PathValue("sub") == "credentials_email":
+		s.updateCredentialsEmail(w, r, r.PathValue("id"))
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (s *Server) deleteUserSubresource(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("id") == "service_accounts":
+		s.deleteServiceAccount(w, r, r.PathValue("sub"))
+	case r.PathValue("sub") == "credentials_email":
+		s.deleteCredentialsEmail(w, r, r.PathValue("id"))
+	default:
+		writeNotFound(w)
+	}
+}
+
+func (s *Server) updateCredentialsEmail(w http.ResponseWriter, r *http.Request, id string) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.credentialsEmail[id]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	email := stringValue(body, "email")
+	if _, set := body["email"]; set && email == "" {
+		writeValidationError(w, "email", "missing", "This field is required.")
+		return
+	}
+	if email != "" && s.emailTaken(email, id) {
+		writeValidationError(w, "email", "duplicate", "Email address is already in use")
+		return
+	}
+	merge(creds, body)
+	writeJSON(w, http.StatusOK, creds)
+}
+
+func (s *Server) sendPasswordReset(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.credentialsEmail[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	out := copyObject(creds)
+	out["password_reset_url"] = s.URL + "/password/reset/" + r.PathValue("id")
+	writeJSON(w, http.StatusOK, out)
+}
+
+// renderCredentialsAPI3 omits the client secret, which the API returns only on creation.
+func renderCredentialsAPI3(creds object) object {
+	out := copyObject(creds)
+	delete(out, "client_secret")
+	delete(out, "user_id")
+	return out
+}
+
+// userCredentialsAPI3 returns the API3 credentials with the ID if they belong to the user. Must be called with s.mu held.
+func (s *Server) userCredentialsAPI3(userID, id string) (object, bool) {
+	creds, ok := s.credentialsAPI3.get(id)
+	if !ok || stringValue(creds, "user_id") != userID {
+		return nil, false
+	}
+	return creds, true
+}
+
+func (s *Server) allCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	out := []object{}
+	for _, creds := range s.credentialsAPI3.list() {
+		if stringValue(creds, "user_id") == id {
+			out = append(out, renderCredentialsAPI3(creds))
+		}
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) createCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	creds := s.credentialsAPI3.insert("", object{
+		"user_id":       id,
+		"client_id":     randomHex(10),
+		"client_secret": randomHex(12),
+		"created_at":    time.Now().UTC().Format(time.RFC3339),
+		"is_disabled":   false,
+		"type":          "api3",
+	})
+	writeJSON(w, http.StatusOK, copyObject(creds))
+}
+
+func (s *Server) getCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.userCredentialsAPI3(r.PathValue("id"), r.PathValue("credentials_api3_id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, renderCredentialsAPI3(creds))
+}
+
+func (s *Server) deleteCredentialsAPI3(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.userCredentialsAPI3(r.PathValue("id"), r.PathValue("credentials_api3_id"))
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	s.credentialsAPI3.delete(stringValue(creds, "id"))
+	writeNoContent(w)
+}
+
+func (s *Server) getUserRoles(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.renderRoles(s.userRoles[id]))
+}
+
+func (s *Server) setUserRoles(w http.ResponseWriter, r *http.Request) {
+	var roleIDs []string
+	if !decodeBody(w, r, &roleIDs) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	for _, roleID := range roleIDs {
+		if _, ok := s.roles.get(roleID); !ok {
+			writeValidationError(w, "role_ids", "invalid", "Role "+roleID+" does not exist")
+			return
+		}
+	}
+	s.userRoles[id] = append([]string{}, roleIDs...)
+	writeJSON(w, http.StatusOK, s.renderRoles(roleIDs))
+}
+
+func (s *Server) getUserAttributeValues(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.users.get(id); !ok {
+		writeNotFound(w)
+		return
+	}
+
+	filter := splitDelim(r.URL.Query().Get("user_attribute_ids"))
+	values := []object{}
+	for _, attribute := range s.userAttribute.list() {
+		attributeID := stringValue(attribute, "id")
+		if len(filter) > 0 && !contains(filter, attributeID) {
+			continue
+		}
+		value, ok := s.userAttributeVals[id+":"+attributeID]
+		if !ok {
+			continue
+		}
+		values = append(values, s.renderUserAttributeValue(id, attribute, value))
+	}
+	writeJSON(w, http.StatusOK, values)
+}
+
+func (s *Server) renderUserAttributeValue(userID string, attribute, value object) object {
+	return object{
+		"user_id":           userID,
+		"user_attribute_id": attribute["id"],
+		"name":              attribute["name"],
+		"label":             attribute["label"],
+		"value":             value["value"],
+		"value_is_hidden":   attribute["value_is_hidden"],
+		"source":            "user",
+		"rank":              1,
+	}
+}
+
+func (s *Server) setUserAttributeValue(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, attributeID := r.PathValue("id"), r.PathValue("attribute_id")
+	attribute, ok := s.userAttribute.get(attributeID)
+	if _, userOK := s.users.get(userID); !ok || !userOK {
+		writeNotFound(w)
+		return
+	}
+
+	value := object{"value": body["value"]}
+	s.userAttributeVals[userID+":"+attributeID] = value
+	writeJSON(w, http.StatusOK, s.renderUserAttributeValue(userID, attribute, value))
+}
+
+func (s *Server) deleteUserAttributeValue(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, attributeID := r.PathValue("id"), r.PathValue("attribute_id")
+	if _, ok := s.users.get(userID); !ok {
+		writeNotFound(w)
+		return
+	}
+	delete(s.userAttributeVals, userID+":"+attributeID)
+	writeNoContent(w)
+}
+
+func (s *Server) createServiceAccount(w http.ResponseWriter, r *http.Request) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stringValue(body, "service_account_name") == "" {
+		writeValidationError(w, "service_account_name", "missing", "This field is required.")
+		return
+	}
+	user := object{"is_disabled": false}
+	merge(user, body)
+	user["is_service_account"] = true
+	s.users.insert("", user)
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) serviceAccount(id string) (object, bool) {
+	user, ok := s.users.get(id)
+	if !ok || user["is_service_account"] != true {
+		return nil, false
+	}
+	return user, true
+}
+
+func (s *Server) updateServiceAccount(w http.ResponseWriter, r *http.Request, id string) {
+	var body object
+	if !decodeBody(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.serviceAccount(id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	merge(user, body, "is_service_account")
+	writeJSON(w, http.StatusOK, s.renderUser(user))
+}
+
+func (s *Server) deleteServiceAccount(w http.ResponseWriter, r *http.Request, id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.serviceAccount(id); !ok {
+		writeNotFound(w)
+		return
+	}
+	s.users.delete(id)
+	s.forgetUser(id)
+	writeNoContent(w)
+}
+
+// splitDelim parses a DelimString query parameter, which the SDK sends JSON-quoted.
+func splitDelim(v string) []string {
+	v = strings.Trim(v, `"`)
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
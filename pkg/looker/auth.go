@@ -0,0 +1,139 @@
+package looker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// appIDTransport sets the header the Looker SDK uses to identify itself on every request.
+type appIDTransport struct {
+	base http.RoundTripper
+}
+
+func (t *appIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("x-looker-appid", "go-sdk")
+	return t.base.RoundTrip(req)
+}
+
+// newAuthSession replaces rtl.NewAuthSessionWithTransport so that requests can be authenticated by any
+// token source, not only by logging in with client credentials.
+func newAuthSession(settings rtl.ApiSettings, source oauth2.TokenSource, transport http.RoundTripper) *rtl.AuthSession {
+	return &rtl.AuthSession{
+		Config: settings,
+		Client: http.Client{
+			Transport: &oauth2.Transport{
+				Source: source,
+				Base:   &appIDTransport{base: transport},
+			},
+		},
+	}
+}
+
+// newClientCredentialsTokenSource logs in with API client credentials and logs in again once the session expires.
+func newClientCredentialsTokenSource(settings rtl.ApiSettings, transport http.RoundTripper) oauth2.TokenSource {
+	config := clientcredentials.Config{
+		ClientID:     settings.ClientId,
+		ClientSecret: settings.ClientSecret,
+		TokenURL:     fmt.Sprintf("%s/api/%s/login", settings.BaseUrl, settings.ApiVersion),
+		AuthStyle:    oauth2.AuthStyleInParams,
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{
+		Transport: &appIDTransport{base: transport},
+	})
+	return config.TokenSource(ctx)
+}
+
+// newAccessTokenSource authenticates every request with a pre-issued access token.
+func newAccessTokenSource(accessToken string) oauth2.TokenSource {
+	return oauth2.StaticTokenSource(&oauth2.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+	})
+}
+
+// fileTokenSource reads the access token from a file maintained by an external process, such as a token broker.
+// The file holds either the bare token or the JSON response of the Looker login endpoint. It is read again
+// once the token expires or the file is modified, so that the provider picks up rotated tokens.
+type fileTokenSource struct {
+	path string
+
+	mu      sync.Mutex
+	token   *oauth2.Token
+	modTime time.Time
+}
+
+func newFileTokenSource(path string) *fileTokenSource {
+	return &fileTokenSource{path: path}
+}
+
+func (s *fileTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading access token file: %w", err)
+	}
+	if s.token.Valid() && info.ModTime().Equal(s.modTime) {
+		return s.token, nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading access token file: %w", err)
+	}
+	token, err := parseTokenFile(content, info.ModTime())
+	if err != nil {
+		return nil, fmt.Errorf("reading access token file %s: %w", s.path, err)
+	}
+	if !token.Valid() {
+		return nil, fmt.Errorf("access token in %s expired at %s", s.path, token.Expiry.Format(time.RFC3339))
+	}
+
+	s.token = token
+	s.modTime = info.ModTime()
+	return token, nil
+}
+
+// parseTokenFile parses the content of an access token file. The expiry of a login response
+// is counted from issuedAt, the time the file was written.
+func parseTokenFile(content []byte, issuedAt time.Time) (*oauth2.Token, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if trimmed == "" {
+		return nil, errors.New("file is empty")
+	}
+
+	if !strings.HasPrefix(trimmed, "{") {
+		return &oauth2.Token{AccessToken: trimmed, TokenType: "Bearer"}, nil
+	}
+
+	var response struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &response); err != nil {
+		return nil, err
+	}
+	if response.AccessToken == "" {
+		return nil, errors.New("access_token is missing")
+	}
+
+	token := &oauth2.Token{AccessToken: response.AccessToken, TokenType: response.TokenType}
+	if response.ExpiresIn > 0 {
+		token.Expiry = issuedAt.Add(time.Duration(response.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,87 @@
+package looker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTokenFile(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		content    string
+		wantToken  string
+		wantExpiry time.Time
+		wantErr    bool
+	}{
+		"bare token": {
+			content:   "abc123\n",
+			wantToken: "abc123",
+		},
+		"login response": {
+			content:    `{"access_token":"abc123","token_type":"Bearer","expires_in":3600}`,
+			wantToken:  "abc123",
+			wantExpiry: issuedAt.Add(time.Hour),
+		},
+		"login response without expiry": {
+			content:   `{"access_token":"abc123"}`,
+			wantToken: "abc123",
+		},
+		"login response without token": {
+			content: `{"token_type":"Bearer"}`,
+			wantErr: true,
+		},
+		"malformed json": {
+			content: `{"access_token":`,
+			wantErr: true,
+		},
+		"empty file": {
+			content: " \n",
+			wantErr: true,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			token, err := parseTokenFile([]byte(tt.content), issuedAt)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantToken, token.AccessToken)
+			assert.Equal(t, tt.wantExpiry, token.Expiry)
+		})
+	}
+}
+
+func TestFileTokenSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	source := newFileTokenSource(path)
+
+	_, err := source.Token()
+	assert.Error(t, err, "missing file")
+
+	require.NoError(t, os.WriteFile(path, []byte("first"), 0o600))
+	token, err := source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "first", token.AccessToken)
+
+	// a rotated token is picked up as soon as the file changes
+	require.NoError(t, os.WriteFile(path, []byte("second"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	token, err = source.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "second", token.AccessToken)
+
+	// an expired login response is reported instead of being sent to Looker
+	require.NoError(t, os.WriteFile(path, []byte(`{"access_token":"third","expires_in":60}`), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(-time.Hour)))
+	_, err = source.Token()
+	assert.ErrorContains(t, err, "expired")
+}
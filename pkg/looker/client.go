@@ -0,0 +1,215 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"golang.org/x/oauth2"
+)
+
+// lookerClient is the provider meta shared by all resources and data sources.
+// Calls made through the embedded LookerSDK act as the provider-level sudo_as_user_id when it is set,
+// and as the configured credentials otherwise.
+type lookerClient struct {
+	*apiclient.LookerSDK
+
+	// admin authenticates with the configured credentials. It is the one logging in as other users.
+	admin     *apiclient.LookerSDK
+	settings  rtl.ApiSettings
+	transport http.RoundTripper
+	// bulkConcurrency is the number of API calls bulk operations, such as adding the members of a group, make at the same time.
+	bulkConcurrency int
+	// allowMissingModels turns off the check that the models of model sets exist.
+	allowMissingModels bool
+	// sudoAsUserID is the provider-level sudo_as_user_id.
+	sudoAsUserID string
+	// newSession returns a token source logging in to a new API session. It is nil when the
+	// authentication mode can't log in again, such as pre-issued access tokens.
+	newSession func() oauth2.TokenSource
+
+	mu          sync.Mutex
+	sudoClients map[string]*apiclient.LookerSDK
+	locks       map[string]*sync.Mutex
+
+	permissionsMu sync.Mutex
+	permissions   []string
+
+	devMu     sync.Mutex
+	devClient *apiclient.LookerSDK
+}
+
+func newLookerClient(settings rtl.ApiSettings, source oauth2.TokenSource, transport http.RoundTripper, sudoAsUserID string) *lookerClient {
+	admin := apiclient.NewLookerSDK(newAuthSession(settings, source, transport))
+	c := &lookerClient{
+		LookerSDK:       admin,
+		admin:           admin,
+		settings:        settings,
+		transport:       transport,
+		bulkConcurrency: defaultBulkConcurrency,
+		sudoAsUserID:    sudoAsUserID,
+		sudoClients:     map[string]*apiclient.LookerSDK{},
+		locks:           map[string]*sync.Mutex{},
+	}
+	if sudoAsUserID != "" {
+		c.LookerSDK = c.sudo(sudoAsUserID)
+	}
+	return c
+}
+
+// sudo returns a client acting as the user with userID. Clients are cached, so every resource
+// acting as the same user shares one sudo session.
+func (c *lookerClient) sudo(userID string) *apiclient.LookerSDK {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if client, ok := c.sudoClients[userID]; ok {
+		return client
+	}
+	source := oauth2.ReuseTokenSource(nil, &sudoTokenSource{admin: c.admin, userID: userID})
+	client := apiclient.NewLookerSDK(newAuthSession(c.settings, source, c.transport))
+	c.sudoClients[userID] = client
+	return client
+}
+
+// lock serializes the read-modify-write updates of a Looker object shared by several resources,
+// such as the roles of a user, so that concurrent resources don't overwrite each other's changes.
+// It returns the function releasing the lock.
+func (c *lookerClient) lock(key string) func() {
+	c.mu.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		c.locks[key] = l
+	}
+	c.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// inDevWorkspace runs fn with a client whose API session is in the dev workspace, which Looker requires
+// to change projects and to read the ones that were never deployed.
+func (c *lookerClient) inDevWorkspace(fn func(dev *apiclient.LookerSDK) error) error {
+	dev, err := c.devSession()
+	if err != nil {
+		return err
+	}
+	// switched on every call, as the session is replaced by a new one in production when it expires
+	if _, err = dev.UpdateSession(apiclient.WriteApiSession{WorkspaceId: ptrTo("dev")}, nil); err != nil {
+		return wrapSDKError(err, "UpdateSession", "session", "workspace_id=dev")
+	}
+	return fn(dev)
+}
+
+// devSession returns the client of the API session the dev workspace is used in. The workspace belongs to the
+// API session, so this session is kept apart from the one shared by every other resource, which stays in production.
+// It logs in again when the authentication mode allows it, and otherwise logs in through LoginUser as the user the
+// provider acts as, which requires the sudo permission.
+func (c *lookerClient) devSession() (*apiclient.LookerSDK, error) {
+	c.devMu.Lock()
+	defer c.devMu.Unlock()
+
+	if c.devClient != nil {
+		return c.devClient, nil
+	}
+	var source oauth2.TokenSource
+	asItself := false
+	switch {
+	case c.sudoAsUserID != "":
+		source = &sudoTokenSource{admin: c.admin, userID: c.sudoAsUserID}
+	case c.newSession != nil:
+		source = c.newSession()
+	default:
+		// pre-issued access tokens can't log in again, so their user logs in as itself
+		me, err := c.admin.Me("id", nil)
+		if err != nil {
+			return nil, wrapSDKError(err, "Me", "user", "me")
+		}
+		source = &sudoTokenSource{admin: c.admin, userID: *me.Id}
+		asItself = true
+	}
+	source = oauth2.ReuseTokenSource(nil, source)
+	// log in now, so that a missing sudo permission is reported as such instead of failing the first call
+	if _, err := source.Token(); err != nil {
+		if asItself && apiStatusCode(err) == http.StatusForbidden {
+			return nil, fmt.Errorf("the dev workspace needs an API session of its own, which access_token and access_token_file "+
+				"can only open with the sudo permission; authenticate with client_id and client_secret instead: %w", err)
+		}
+		return nil, err
+	}
+	c.devClient = apiclient.NewLookerSDK(newAuthSession(c.settings, source, c.transport))
+	return c.devClient, nil
+}
+
+// permissionNames returns the permissions of the instance. They only change with Looker releases,
+// so they are fetched once and shared by every permission set of the plan.
+func (c *lookerClient) permissionNames() ([]string, error) {
+	c.permissionsMu.Lock()
+	defer c.permissionsMu.Unlock()
+
+	if c.permissions != nil {
+		return c.permissions, nil
+	}
+	permissions, err := c.admin.AllPermissions(nil)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if permission.Permission != nil {
+			names = append(names, *permission.Permission)
+		}
+	}
+	c.permissions = names
+	return names, nil
+}
+
+// sudoTokenSource obtains access tokens acting as a user through the admin's LoginUser call.
+// The sessions are associative, so that the activity of the sudo sessions is credited to the admin in the audit trail.
+type sudoTokenSource struct {
+	admin  *apiclient.LookerSDK
+	userID string
+}
+
+func (s *sudoTokenSource) Token() (*oauth2.Token, error) {
+	accessToken, err := s.admin.LoginUser(s.userID, true, nil)
+	if err != nil {
+		return nil, wrapSDKError(err, "LoginUser", "user", "%s", s.userID)
+	}
+	if accessToken.AccessToken == nil {
+		return nil, errors.New("LoginUser returned no access token")
+	}
+
+	token := &oauth2.Token{AccessToken: *accessToken.AccessToken}
+	if accessToken.TokenType != nil {
+		token.TokenType = *accessToken.TokenType
+	}
+	if accessToken.ExpiresIn != nil {
+		token.Expiry = time.Now().Add(time.Duration(*accessToken.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// sudoAsUserIDSchema is the per-resource override of the provider-level sudo_as_user_id.
+func sudoAsUserIDSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "ID of the user to act as when managing this resource, overriding the provider-level `sudo_as_user_id`. Needed for content that only its owner can manage, such as the content of personal folders",
+	}
+}
+
+// clientFor returns the client a resource with a sudo_as_user_id argument talks to Looker with.
+func clientFor(d *schema.ResourceData, m interface{}) *apiclient.LookerSDK {
+	client := m.(*lookerClient)
+	if userID, ok := d.GetOk("sudo_as_user_id"); ok {
+		return client.sudo(userID.(string))
+	}
+	return client.LookerSDK
+}
@@ -0,0 +1,147 @@
+package looker
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestLookerClient_Sudo(t *testing.T) {
+	srv, admin := newTestClient(t)
+	settings := admin.settings
+
+	firstName := "Jane"
+	user, err := admin.CreateUser(apiclient.WriteUser{FirstName: &firstName}, "", nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		sudoAsUserID string
+		wantUserID   string
+		wantErr      bool
+	}{
+		"without sudo": {
+			sudoAsUserID: "",
+			wantUserID:   fakelooker.AdminUserID,
+		},
+		"sudo as user": {
+			sudoAsUserID: *user.Id,
+			wantUserID:   *user.Id,
+		},
+		"sudo as unknown user": {
+			sudoAsUserID: "404",
+			wantErr:      true,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			client := newLookerClient(settings, newAccessTokenSource(srv.IssueToken(fakelooker.AdminUserID)), http.DefaultTransport, tt.sudoAsUserID)
+
+			me, err := client.Me("", nil)
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "LoginUser failed")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUserID, *me.Id)
+		})
+	}
+
+	// resources acting as the same user share one sudo session
+	assert.Same(t, admin.sudo(*user.Id), admin.sudo(*user.Id))
+}
+
+func TestLookerClient_InDevWorkspace(t *testing.T) {
+	srv, admin := newTestClient(t)
+	settings := admin.settings
+	user, err := admin.CreateUser(apiclient.WriteUser{FirstName: ptrTo("Jane")}, "", nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		newClient  func() *lookerClient
+		wantUserID string
+		err        error
+		wantErr    string
+	}{
+		"access token": {
+			newClient: func() *lookerClient {
+				return newLookerClient(settings, newAccessTokenSource(srv.IssueToken(fakelooker.AdminUserID)), http.DefaultTransport, "")
+			},
+			wantUserID: fakelooker.AdminUserID,
+		},
+		"client credentials": {
+			newClient: func() *lookerClient {
+				settings := settings
+				settings.ClientId, settings.ClientSecret = fakelooker.ClientID, fakelooker.ClientSecret
+				client := newLookerClient(settings, newClientCredentialsTokenSource(settings, http.DefaultTransport), http.DefaultTransport, "")
+				client.newSession = func() oauth2.TokenSource {
+					return newClientCredentialsTokenSource(settings, http.DefaultTransport)
+				}
+				return client
+			},
+			wantUserID: fakelooker.AdminUserID,
+		},
+		"sudo": {
+			newClient: func() *lookerClient {
+				return newLookerClient(settings, newAccessTokenSource(srv.IssueToken(fakelooker.AdminUserID)), http.DefaultTransport, *user.Id)
+			},
+			wantUserID: *user.Id,
+		},
+		"access token without the sudo permission": {
+			newClient: func() *lookerClient {
+				return newLookerClient(settings, newAccessTokenSource(srv.IssueToken(*user.Id)), http.DefaultTransport, "")
+			},
+			wantErr: "authenticate with client_id and client_secret instead",
+		},
+		"failure": {
+			newClient: func() *lookerClient {
+				return newLookerClient(settings, newAccessTokenSource(srv.IssueToken(fakelooker.AdminUserID)), http.DefaultTransport, "")
+			},
+			wantUserID: fakelooker.AdminUserID,
+			err:        errors.New("failed"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			client := tt.newClient()
+			if tt.wantErr != "" {
+				err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+					t.Fatal("fn must not be called")
+					return nil
+				})
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+				session, err := dev.Session(nil)
+				require.NoError(t, err)
+				assert.Equal(t, "dev", *session.WorkspaceId)
+				me, err := dev.Me("", nil)
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantUserID, *me.Id)
+
+				// the session shared by the other resources stays in production meanwhile
+				session, err = client.Session(nil)
+				require.NoError(t, err)
+				assert.Equal(t, "production", *session.WorkspaceId)
+				return tt.err
+			})
+			assert.Equal(t, tt.err, err)
+
+			// the dev session is reused
+			first, err := client.devSession()
+			require.NoError(t, err)
+			second, err := client.devSession()
+			require.NoError(t, err)
+			assert.Same(t, first, second)
+		})
+	}
+}
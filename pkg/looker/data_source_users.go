@@ -7,7 +7,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func dataSourceUsers() *schema.Resource {
@@ -52,25 +51,23 @@ func dataSourceUsers() *schema.Resource {
 }
 
 func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
-	request := apiclient.RequestAllUsers{}
-	users, err := client.AllUsers(request, nil)
-
+	client := m.(*lookerClient)
+	users, err := allUsers(client.LookerSDK)
 	if err != nil {
-		return diag.FromErr(err)
+		return diag.FromErr(wrapSDKError(err, "AllUsers", "users", ""))
 	}
 
 	userList := make([]map[string]interface{}, len(users))
 	userEmails := make([]string, len(users))
 	for i, user := range users {
 		userList[i] = map[string]interface{}{
-			"id":          *user.Id,
-			"email":       *user.Email,
-			"first_name":  *user.FirstName,
-			"last_name":   *user.LastName,
-			"is_disabled": *user.IsDisabled,
+			"id":          valueOrZero(user.Id),
+			"email":       valueOrZero(user.Email),
+			"first_name":  valueOrZero(user.FirstName),
+			"last_name":   valueOrZero(user.LastName),
+			"is_disabled": valueOrZero(user.IsDisabled),
 		}
-		userEmails[i] = *user.Email
+		userEmails[i] = valueOrZero(user.Email)
 	}
 
 	if err := d.Set("users", userList); err != nil {
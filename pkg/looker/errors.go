@@ -1,9 +1,134 @@
 package looker
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sdkErrorPattern matches the error string the Looker Go SDK builds for non-2xx responses:
+//
+//	response error. status=404 Not Found. error={"message":"Not found","documentation_url":"..."}
+var sdkErrorPattern = regexp.MustCompile(`(?s)response error\. status=(\d{3})[^.]*\.(?: error=(.*))?`)
+
+// maxRawErrorBodyLength caps how much of a non-JSON error body (e.g. an HTML page from a proxy) is kept.
+const maxRawErrorBodyLength = 200
+
+// apiError is a Looker API error response parsed out of an SDK error.
+type apiError struct {
+	StatusCode       int
+	Message          string
+	DocumentationURL string
+	Errors           []apiFieldError
+
+	err error
+}
+
+// apiFieldError describes a single invalid field of a 422 Validation Failed response.
+type apiFieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Looker API returned %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	for _, fe := range e.Errors {
+		fmt.Fprintf(&b, "; %s %s", fe.Field, fe.Code)
+		if fe.Message != "" {
+			fmt.Fprintf(&b, " (%s)", fe.Message)
+		}
+	}
+	if e.DocumentationURL != "" {
+		fmt.Fprintf(&b, ". See %s", e.DocumentationURL)
+	}
+	return b.String()
+}
+
+func (e *apiError) Unwrap() error {
+	return e.err
+}
+
+// parseAPIError extracts the status code, message and documentation URL from an SDK error.
+// It returns false when err does not originate from a Looker API response (e.g. network errors).
+func parseAPIError(err error) (*apiError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var parsed *apiError
+	if errors.As(err, &parsed) {
+		return parsed, true
+	}
+
+	matches := sdkErrorPattern.FindStringSubmatch(err.Error())
+	if matches == nil {
+		return nil, false
+	}
+	statusCode, convErr := strconv.Atoi(matches[1])
+	if convErr != nil {
+		return nil, false
+	}
+
+	parsed = &apiError{StatusCode: statusCode, err: err}
+
+	body := strings.TrimSpace(matches[2])
+	var payload struct {
+		Message          string          `json:"message"`
+		DocumentationURL string          `json:"documentation_url"`
+		Errors           []apiFieldError `json:"errors"`
+	}
+	if jsonErr := json.Unmarshal([]byte(body), &payload); jsonErr == nil {
+		parsed.Message = payload.Message
+		parsed.DocumentationURL = payload.DocumentationURL
+		parsed.Errors = payload.Errors
+	} else if body != "" {
+		if len(body) > maxRawErrorBodyLength {
+			body = body[:maxRawErrorBodyLength] + "..."
+		}
+		parsed.Message = body
+	}
+
+	return parsed, true
+}
+
+// apiStatusCode returns the HTTP status code of a Looker API error, or 0 if err is not one.
+func apiStatusCode(err error) int {
+	if parsed, ok := parseAPIError(err); ok {
+		return parsed.StatusCode
+	}
+	return 0
+}
+
+// isNotFound reports whether err means the requested object does not exist (anymore).
+func isNotFound(err error) bool {
+	return apiStatusCode(err) == http.StatusNotFound
+}
+
+// isRetryableStatus reports whether a request failing with one of the retryable status codes can be sent again.
+// Requests that aren't idempotent may have been processed by Looker before it failed, so they are only retried
+// when Looker turned them away: throttled, or unavailable with a Retry-After header.
+func isRetryableStatus(method string, statusCode int, retryAfter string) bool {
+	if isIdempotent(method) {
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests ||
+		(statusCode == http.StatusServiceUnavailable && retryAfter != "")
+}
 
 // wrapSDKError wraps an SDK error with additional context about the operation and resource.
 // This makes error messages more informative by showing which API call failed and on what resource.
+// Errors returned by the Looker API are replaced by their parsed form, so the message shows the
+// status, the Looker error message and the documentation link instead of the raw response body.
+// The original error remains available through errors.Unwrap.
 //
 // The format and args parameters work like fmt.Sprintf to build the resource identifier.
 // Common patterns:
@@ -13,13 +138,17 @@ import "fmt"
 //
 // Example outputs:
 //
-//	CreateConnection failed for connection "my-db-connection": response error. status=422. error={"message":"already_exists"}
-//	UpdateConnection failed for connection "name=my-db-connection, id=123": response error. status=404. error={"message":"not_found"}
+//	CreateConnection failed for connection "my-db-connection": Looker API returned 422 Unprocessable Entity: Validation Failed; name already_exists
+//	UpdateConnection failed for connection "name=my-db-connection, id=123": Looker API returned 404 Not Found: Not found. See https://cloud.google.com/looker/docs/r/api/support
 func wrapSDKError(err error, operation string, resourceType string, format string, args ...interface{}) error {
 	if err == nil {
 		return nil
 	}
 
+	if parsed, ok := parseAPIError(err); ok {
+		err = parsed
+	}
+
 	if format == "" {
 		// For operations without a specific resource identifier (e.g., list operations)
 		return fmt.Errorf("%s failed for %s: %w", operation, resourceType, err)
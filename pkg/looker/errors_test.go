@@ -2,6 +2,8 @@ package looker
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -51,6 +53,15 @@ func TestWrapSDKError(t *testing.T) {
 			wantErr:      true,
 			wantMsg:      `UpdateUser failed for user "email=a@example.com, id=42": not found`,
 		},
+		"api error is replaced by its parsed form": {
+			err:          errors.New(`response error. status=404 Not Found. error={"message":"Not found","documentation_url":"https://docs.example.com"}`),
+			operation:    "Group",
+			resourceType: "group",
+			format:       "%s",
+			args:         []interface{}{"7"},
+			wantErr:      true,
+			wantMsg:      `Group failed for group "7": Looker API returned 404 Not Found: Not found. See https://docs.example.com`,
+		},
 	}
 
 	for key, tt := range tests {
@@ -64,3 +75,148 @@ func TestWrapSDKError(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAPIError(t *testing.T) {
+	tests := map[string]struct {
+		err      error
+		wantOK   bool
+		expected apiError
+	}{
+		"not found": {
+			err:    errors.New(`response error. status=404 Not Found. error={"message":"Not found","documentation_url":"https://docs.example.com"}`),
+			wantOK: true,
+			expected: apiError{
+				StatusCode:       404,
+				Message:          "Not found",
+				DocumentationURL: "https://docs.example.com",
+			},
+		},
+		"validation failed": {
+			err:    errors.New(`response error. status=422 Unprocessable Entity. error={"message":"Validation Failed","errors":[{"field":"name","code":"already_exists","message":"Name is taken"}]}`),
+			wantOK: true,
+			expected: apiError{
+				StatusCode: 422,
+				Message:    "Validation Failed",
+				Errors:     []apiFieldError{{Field: "name", Code: "already_exists", Message: "Name is taken"}},
+			},
+		},
+		"non json body": {
+			err:    errors.New(`response error. status=502 Bad Gateway. error=<html>bad gateway</html>`),
+			wantOK: true,
+			expected: apiError{
+				StatusCode: 502,
+				Message:    "<html>bad gateway</html>",
+			},
+		},
+		"wrapped api error": {
+			err:    fmt.Errorf("context: %w", errors.New(`response error. status=500 Internal Server Error. error={}`)),
+			wantOK: true,
+			expected: apiError{
+				StatusCode: 500,
+			},
+		},
+		"network error": {
+			err:    errors.New("dial tcp 127.0.0.1:443: connect: connection refused"),
+			wantOK: false,
+		},
+		"nil error": {
+			err:    nil,
+			wantOK: false,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			actual, ok := parseAPIError(tt.err)
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.expected.StatusCode, actual.StatusCode)
+				assert.Equal(t, tt.expected.Message, actual.Message)
+				assert.Equal(t, tt.expected.DocumentationURL, actual.DocumentationURL)
+				assert.Equal(t, tt.expected.Errors, actual.Errors)
+				assert.ErrorIs(t, actual, tt.err)
+			}
+		})
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	tests := map[string]struct {
+		err          error
+		wantNotFound bool
+	}{
+		"not found": {
+			err:          errors.New(`response error. status=404 Not Found. error={"message":"Not found"}`),
+			wantNotFound: true,
+		},
+		"not found wrapped by wrapSDKError": {
+			err:          wrapSDKError(errors.New(`response error. status=404 Not Found. error={"message":"Not found"}`), "User", "user", "%s", "1"),
+			wantNotFound: true,
+		},
+		"internal server error": {
+			err: errors.New(`response error. status=500 Internal Server Error. error={"message":"boom"}`),
+		},
+		"too many requests": {
+			err: errors.New(`response error. status=429 Too Many Requests. error={"message":"slow down"}`),
+		},
+		"validation failed": {
+			err: errors.New(`response error. status=422 Unprocessable Entity. error={"message":"Validation Failed"}`),
+		},
+		"message mentioning 404 is not an api error": {
+			err: errors.New("user 404 has no email"),
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, tt.wantNotFound, isNotFound(tt.err))
+		})
+	}
+}
+
+func TestIsRetryableStatus(t *testing.T) {
+	tests := map[string]struct {
+		method     string
+		statusCode int
+		retryAfter string
+		expected   bool
+	}{
+		"idempotent request on gateway error": {
+			method:     http.MethodGet,
+			statusCode: http.StatusBadGateway,
+			expected:   true,
+		},
+		"idempotent request on server error": {
+			method:     http.MethodDelete,
+			statusCode: http.StatusInternalServerError,
+			expected:   true,
+		},
+		"non-idempotent request on gateway error": {
+			method:     http.MethodPost,
+			statusCode: http.StatusBadGateway,
+			expected:   false,
+		},
+		"non-idempotent request throttled": {
+			method:     http.MethodPatch,
+			statusCode: http.StatusTooManyRequests,
+			expected:   true,
+		},
+		"non-idempotent request unavailable with Retry-After": {
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			retryAfter: "1",
+			expected:   true,
+		},
+		"non-idempotent request unavailable without Retry-After": {
+			method:     http.MethodPost,
+			statusCode: http.StatusServiceUnavailable,
+			expected:   false,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isRetryableStatus(tt.method, tt.statusCode, tt.retryAfter))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package looker
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// requestIDHeader correlates the log lines of a request with the Looker server logs.
+	requestIDHeader = "X-Request-Id"
+	// maxLoggedBodyLength caps how much of a request or response body is logged.
+	maxLoggedBodyLength = 8192
+	redacted            = "<redacted>"
+)
+
+// sensitiveFields are the JSON keys, form fields and query parameters whose values are never logged.
+var sensitiveFields = map[string]bool{
+	"client_secret": true,
+	"password":      true,
+	"certificate":   true,
+	"access_token":  true,
+	"refresh_token": true,
+	"token":         true,
+	"private_key":   true,
+	"client_key":    true,
+	"api_key":       true,
+	"secret":        true,
+	// JSON holding the credentials of scheduled plan destinations, like S3 secret keys and SFTP passwords
+	"secret_parameters": true,
+}
+
+// sensitiveSuffixes catch the secret fields that are not listed in sensitiveFields, like git_password or deploy_secret.
+var sensitiveSuffixes = []string{"_password", "_secret", "_key"}
+
+// logTransport is an http.RoundTripper that logs every request and response through tflog:
+// method, path, status, latency and request ID at DEBUG, and the redacted bodies at TRACE.
+type logTransport struct {
+	base http.RoundTripper
+	// ctx carries the provider logger. The SDK sends requests with a context of its own, which has none.
+	ctx context.Context
+}
+
+func newLogTransport(ctx context.Context, base http.RoundTripper) *logTransport {
+	return &logTransport{base: base, ctx: context.WithoutCancel(ctx)}
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	requestID := req.Header.Get(requestIDHeader)
+	if requestID == "" {
+		requestID = newRequestID()
+		req = req.Clone(req.Context())
+		req.Header.Set(requestIDHeader, requestID)
+	}
+
+	fields := map[string]interface{}{
+		"method":     req.Method,
+		"path":       req.URL.Path,
+		"request_id": requestID,
+	}
+	if req.URL.RawQuery != "" {
+		fields["query"] = redactQuery(req.URL.Query())
+	}
+	tflog.Debug(t.ctx, "Sending Looker API request", fields)
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			content, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodyLength))
+			body.Close()
+			if len(content) > 0 {
+				tflog.Trace(t.ctx, "Looker API request body", map[string]interface{}{
+					"request_id": requestID,
+					"body":       redactBody(req.Header.Get("Content-Type"), content),
+				})
+			}
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(t.ctx, "Looker API request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	tflog.Debug(t.ctx, "Received Looker API response", fields)
+
+	content, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	// hand the body back to the SDK whether or not it could be read completely
+	resp.Body = io.NopCloser(io.MultiReader(bytes.NewReader(content), errorReader{err}))
+	if len(content) > 0 {
+		if len(content) > maxLoggedBodyLength {
+			content = content[:maxLoggedBodyLength]
+		}
+		tflog.Trace(t.ctx, "Looker API response body", map[string]interface{}{
+			"request_id": requestID,
+			"body":       redactBody(resp.Header.Get("Content-Type"), content),
+		})
+	}
+	return resp, nil
+}
+
+// errorReader returns err, or io.EOF if err is nil, on every read.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// redactBody returns a loggable representation of a body with the values of sensitive fields replaced.
+func redactBody(contentType string, content []byte) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(content))
+		if err != nil {
+			return redacted
+		}
+		return redactQuery(values)
+	case mediaType == "application/json" || json.Valid(content):
+		var v interface{}
+		if err := json.Unmarshal(content, &v); err != nil {
+			// most likely truncated, which makes it impossible to tell secrets apart
+			return redacted
+		}
+		var out strings.Builder
+		encoder := json.NewEncoder(&out)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(redactJSON(v)); err != nil {
+			return redacted
+		}
+		return strings.TrimSuffix(out.String(), "\n")
+	default:
+		return string(content)
+	}
+}
+
+// redactJSON replaces the values of sensitive keys anywhere in a decoded JSON document.
+func redactJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveField(key) && value != nil {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactQuery encodes form fields or query parameters with the values of sensitive ones replaced.
+func redactQuery(values url.Values) string {
+	out := url.Values{}
+	for key, vs := range values {
+		if isSensitiveField(key) {
+			out[key] = []string{redacted}
+		} else {
+			out[key] = vs
+		}
+	}
+	return out.Encode()
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	if sensitiveFields[name] {
+		return true
+	}
+	for _, suffix := range sensitiveSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
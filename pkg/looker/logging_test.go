@@ -0,0 +1,139 @@
+package looker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/terraform-plugin-log/tfsdklog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactBody(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		want        string
+	}{
+		"json": {
+			contentType: "application/json",
+			body:        `{"name":"snowflake","password":"hunter2","certificate":"base64"}`,
+			want:        `{"certificate":"<redacted>","name":"snowflake","password":"<redacted>"}`,
+		},
+		"nested json": {
+			contentType: "application/json; charset=utf-8",
+			body:        `[{"id":"1","credentials":{"Client_Secret":"s3cr3t","client_id":"abc"}}]`,
+			want:        `[{"credentials":{"Client_Secret":"<redacted>","client_id":"abc"},"id":"1"}]`,
+		},
+		"scheduled plan destination": {
+			contentType: "application/json",
+			body:        `{"name":"daily","scheduled_plan_destination":[{"type":"s3","secret_parameters":"{\"secret_access_key\":\"s3cr3t\"}"}]}`,
+			want:        `{"name":"daily","scheduled_plan_destination":[{"secret_parameters":"<redacted>","type":"s3"}]}`,
+		},
+		"secret suffixes": {
+			contentType: "application/json",
+			body:        `{"git_password":"hunter2","deploy_secret":"s3cr3t","ssh_key":"abc","git_username":"looker"}`,
+			want:        `{"deploy_secret":"<redacted>","git_password":"<redacted>","git_username":"looker","ssh_key":"<redacted>"}`,
+		},
+		"null secret is kept": {
+			contentType: "application/json",
+			body:        `{"password":null}`,
+			want:        `{"password":null}`,
+		},
+		"json without content type": {
+			body: `{"access_token":"abc","token_type":"Bearer","expires_in":3600}`,
+			want: `{"access_token":"<redacted>","expires_in":3600,"token_type":"Bearer"}`,
+		},
+		"truncated json": {
+			contentType: "application/json",
+			body:        `{"name":"snowflake","password":"hun`,
+			want:        "<redacted>",
+		},
+		"form": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "client_id=abc&client_secret=s3cr3t&grant_type=client_credentials",
+			want:        "client_id=abc&client_secret=%3Credacted%3E&grant_type=client_credentials",
+		},
+		"plain text": {
+			contentType: "text/plain",
+			body:        "Not Found",
+			want:        "Not Found",
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody(tt.contentType, []byte(tt.body)))
+		})
+	}
+}
+
+func TestRedactQuery(t *testing.T) {
+	values := url.Values{
+		"fields":       {"id,email"},
+		"access_token": {"abc"},
+	}
+	assert.Equal(t, "access_token=%3Credacted%3E&fields=id%2Cemail", redactQuery(values))
+}
+
+func TestLogTransport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get(requestIDHeader))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "client_id=abc&client_secret=s3cr3t", string(body))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"t0k3n","token_type":"Bearer"}`))
+	}))
+	defer srv.Close()
+
+	ctx, logs := newTestLogger(t)
+	client := &http.Client{Transport: newLogTransport(ctx, http.DefaultTransport)}
+
+	resp, err := client.PostForm(srv.URL+"/api/4.0/login", url.Values{"client_id": {"abc"}, "client_secret": {"s3cr3t"}})
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, `{"access_token":"t0k3n","token_type":"Bearer"}`, string(body), "the SDK must still receive the response body")
+
+	output := logs()
+	assert.Contains(t, output, `"@message":"Sending Looker API request"`)
+	assert.Contains(t, output, `"@message":"Received Looker API response"`)
+	assert.Contains(t, output, `"method":"POST"`)
+	assert.Contains(t, output, `"path":"/api/4.0/login"`)
+	assert.Contains(t, output, `"status":200`)
+	assert.Contains(t, output, `"latency_ms"`)
+	assert.Contains(t, output, `"request_id"`)
+	assert.NotContains(t, output, "s3cr3t")
+	assert.NotContains(t, output, "t0k3n")
+}
+
+// newTestLogger returns a context with a provider logger logging at TRACE, and a function returning what it logged.
+func newTestLogger(t *testing.T) (context.Context, func() string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "log")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+
+	// the root logger writes to whatever os.Stderr is when it is created
+	stderr := os.Stderr
+	os.Stderr = f
+	ctx := tfsdklog.NewRootProviderLogger(context.Background(), tfsdklog.WithLevel(hclog.Trace))
+	os.Stderr = stderr
+
+	return ctx, func() string {
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return strings.TrimSpace(string(content))
+	}
+}
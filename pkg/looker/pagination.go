@@ -0,0 +1,45 @@
+package looker
+
+import (
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+// defaultPageSize is the number of items requested per call from endpoints paginated with limit and offset.
+const defaultPageSize int64 = 500
+
+// paginate calls fetch with increasing offsets until it returns a page shorter than pageSize, and returns
+// the items of all the pages. Endpoints without limit and offset return everything in one call and need no paging.
+func paginate[T any](pageSize int64, fetch func(limit, offset int64) ([]T, error)) ([]T, error) {
+	var all []T
+	for offset := int64(0); ; offset += pageSize {
+		page, err := fetch(pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, page...)
+		if int64(len(page)) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// allGroupUsers returns every user that is a direct member of the group.
+func allGroupUsers(client *apiclient.LookerSDK, groupID string) ([]apiclient.User, error) {
+	return paginate(defaultPageSize, func(limit, offset int64) ([]apiclient.User, error) {
+		return client.AllGroupUsers(apiclient.RequestAllGroupUsers{
+			GroupId: groupID,
+			Limit:   &limit,
+			Offset:  &offset,
+		}, nil)
+	})
+}
+
+// allUsers returns every user of the instance.
+func allUsers(client *apiclient.LookerSDK) ([]apiclient.User, error) {
+	return paginate(defaultPageSize, func(limit, offset int64) ([]apiclient.User, error) {
+		return client.AllUsers(apiclient.RequestAllUsers{
+			Limit:  &limit,
+			Offset: &offset,
+		}, nil)
+	})
+}
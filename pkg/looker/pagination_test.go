@@ -0,0 +1,72 @@
+package looker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaginate(t *testing.T) {
+	tests := map[string]struct {
+		total     int
+		pageSize  int64
+		wantCalls int
+	}{
+		"empty": {
+			total:     0,
+			pageSize:  3,
+			wantCalls: 1,
+		},
+		"single short page": {
+			total:     2,
+			pageSize:  3,
+			wantCalls: 1,
+		},
+		"exact multiple of page size": {
+			total:     6,
+			pageSize:  3,
+			wantCalls: 3,
+		},
+		"last page is short": {
+			total:     7,
+			pageSize:  3,
+			wantCalls: 3,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			items := make([]int, tt.total)
+			for i := range items {
+				items[i] = i
+			}
+
+			calls := 0
+			got, err := paginate(tt.pageSize, func(limit, offset int64) ([]int, error) {
+				calls++
+				assert.Equal(t, tt.pageSize, limit)
+				start := min(int(offset), len(items))
+				end := min(start+int(limit), len(items))
+				return items[start:end], nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, items, append([]int{}, got...))
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	calls := 0
+	_, err := paginate(2, func(limit, offset int64) ([]string, error) {
+		calls++
+		if offset > 0 {
+			return nil, errors.New("boom")
+		}
+		return []string{"a", "b"}, nil
+	})
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 2, calls)
+}
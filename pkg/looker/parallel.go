@@ -0,0 +1,71 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// defaultBulkConcurrency is the number of API calls bulk operations have in flight at the same time.
+const defaultBulkConcurrency = 8
+
+// forEachParallel calls fn for every item, with at most concurrency calls in flight at the same time.
+// Once ctx is done, no new calls are started. It waits for the calls in flight and returns the errors of
+// all the failed calls joined with errors.Join, in the order of items, so that one failure doesn't hide the others.
+func forEachParallel[T any](ctx context.Context, concurrency int, items []T, fn func(item T) error) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	errs := make([]error, len(items))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		if !acquire(ctx, semaphore) {
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = fn(item)
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// acquire takes a slot of the semaphore. It returns false without taking one once ctx is done.
+func acquire(ctx context.Context, semaphore chan struct{}) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	select {
+	case semaphore <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// diagsFromErr turns every error joined with errors.Join into a diagnostic of its own.
+func diagsFromErr(err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var diags diag.Diagnostics
+		for _, err := range joined.Unwrap() {
+			diags = append(diags, diagsFromErr(err)...)
+		}
+		return diags
+	}
+	return diag.FromErr(err)
+}
@@ -0,0 +1,109 @@
+package looker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEachParallel(t *testing.T) {
+	tests := map[string]struct {
+		items       int
+		concurrency int
+		failing     map[int]bool
+		wantErrs    []string
+	}{
+		"all succeed": {
+			items:       20,
+			concurrency: 4,
+		},
+		"errors are aggregated in the order of items": {
+			items:       20,
+			concurrency: 4,
+			failing:     map[int]bool{3: true, 17: true, 8: true},
+			wantErrs:    []string{"item 3 failed", "item 8 failed", "item 17 failed"},
+		},
+		"concurrency below one runs sequentially": {
+			items:       5,
+			concurrency: 0,
+		},
+		"no items": {
+			items:       0,
+			concurrency: 4,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			items := make([]int, tt.items)
+			for i := range items {
+				items[i] = i
+			}
+
+			var inFlight, maxInFlight, calls atomic.Int32
+			err := forEachParallel(context.Background(), tt.concurrency, items, func(item int) error {
+				calls.Add(1)
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				if tt.failing[item] {
+					return fmt.Errorf("item %d failed", item)
+				}
+				return nil
+			})
+
+			assert.Equal(t, int32(tt.items), calls.Load(), "every item is processed even when some fail")
+			assert.LessOrEqual(t, maxInFlight.Load(), int32(max(tt.concurrency, 1)))
+
+			diags := diagsFromErr(err)
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err)
+				assert.Empty(t, diags)
+				return
+			}
+			var got []string
+			for _, d := range diags {
+				got = append(got, d.Summary)
+			}
+			assert.Equal(t, tt.wantErrs, got)
+		})
+	}
+}
+
+func TestForEachParallel_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var calls atomic.Int32
+	err := forEachParallel(ctx, 2, make([]int, 100), func(int) error {
+		if calls.Add(1) == 4 {
+			cancel()
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Less(t, calls.Load(), int32(100), "no new calls are started once ctx is done")
+}
+
+func TestDiagsFromErr(t *testing.T) {
+	assert.Nil(t, diagsFromErr(nil))
+	assert.Len(t, diagsFromErr(errors.New("boom")), 1)
+
+	diags := diagsFromErr(errors.Join(errors.New("a"), errors.Join(errors.New("b"), errors.New("c"))))
+	require.Len(t, diags, 3)
+	assert.Equal(t, "c", diags[2].Summary)
+}
@@ -2,15 +2,26 @@ package looker
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/looker-open-source/sdk-codegen/go/rtl"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"golang.org/x/oauth2"
+	"gopkg.in/ini.v1"
 )
 
 const (
-	defaultAPIVersion = "4.0"
+	defaultAPIVersion    = "4.0"
+	defaultConfigSection = "Looker"
 )
 
 func Provider() *schema.Provider {
@@ -18,19 +29,33 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"client_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKER_API_CLIENT_ID", nil),
-				Description: "Client ID to authenticate with Looker",
+				Description: "Client ID to authenticate with Looker. Conflicts with `access_token` and `access_token_file`",
 			},
 			"client_secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKER_API_CLIENT_SECRET", nil),
-				Description: "Client Secret to authenticate with Looker",
+				Description: "Client Secret to authenticate with Looker. Conflicts with `access_token` and `access_token_file`",
+			},
+			"access_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_ACCESS_TOKEN", nil),
+				Description: "Pre-issued access token to authenticate with Looker. Conflicts with `client_id`, `client_secret` and `access_token_file`",
+			},
+			"access_token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_ACCESS_TOKEN_FILE", nil),
+				Description: "Path to a file holding an access token, either the bare token or the JSON response of the Looker login endpoint. The file is read again when the token expires or the file changes. Conflicts with `client_id`, `client_secret` and `access_token`",
 			},
 			"base_url": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKER_API_BASE_URL", nil),
 				Description: "Looker API Base URL",
 			},
@@ -42,30 +67,150 @@ func Provider() *schema.Provider {
 			"verify_ssl": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("LOOKER_VERIFY_SSL", true),
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_VERIFY_SSL", nil),
+				Description: "Whether to verify the TLS certificate of the Looker instance. Defaults to `true`",
+			},
+			"sudo_as_user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_SUDO_AS_USER_ID", nil),
+				Description: "ID of the user to act as for every API call, using the sudo login of the configured credentials. Resources managing content can override it with their own `sudo_as_user_id`. The activity of sudo sessions is credited to the configured credentials",
 			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("LOOKER_TIMEOUT", nil),
 			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("LOOKER_CA_CERT_FILE", nil),
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path to a PEM file with additional CA certificates to trust, e.g. the one of a TLS-intercepting proxy",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM-encoded additional CA certificates to trust, e.g. the one of a TLS-intercepting proxy",
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+				Description:  "PEM-encoded client certificate presented to the Looker instance for mutual TLS",
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+				Description:  "PEM-encoded private key of `client_cert`",
+			},
+			"proxy_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_PROXY_URL", nil),
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https", "socks5"}),
+				Description:  "URL of the proxy to send requests to the Looker API through",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_CONFIG_FILE", nil),
+				Description: "Path to a `looker.ini` file to read `base_url`, `client_id`, `client_secret`, `verify_ssl` and `timeout` from. Arguments and environment variables take precedence over the file",
+			},
+			"config_section": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_CONFIG_SECTION", defaultConfigSection),
+				Description: "Section of `config_file` to read. Defaults to `Looker`",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_MAX_RETRIES", defaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of times a request failing with a retryable status code is retried. Set to 0 to disable retries",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_RETRY_WAIT_MIN", defaultRetryWaitMin),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait before retrying a request. The wait doubles on every attempt",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_RETRY_WAIT_MAX", defaultRetryWaitMax),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait before retrying a request. A `Retry-After` header sent by Looker takes precedence",
+			},
+			"retryable_status_codes": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(400, 599),
+				},
+				Description: "HTTP status codes on which requests are retried. Defaults to 429, 502, 503 and 504. GET, HEAD, OPTIONS, PUT and DELETE requests are retried on all of them. POST and PATCH requests, which Looker may have processed already, are only retried on 429, and on 503 with a Retry-After header, when these are listed",
+			},
+			"max_requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_MAX_REQUESTS_PER_SECOND", 0.0),
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum number of requests per second sent to the Looker API across all resources and data sources. Set to 0 for no limit",
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests in flight to the Looker API at the same time. Set to 0 for no limit",
+			},
+			"allow_missing_models": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("LOOKER_ALLOW_MISSING_MODELS", false),
+				Description: "Allow `looker_model_set` to reference models that don't exist yet, such as models created by a later deployment. By default, unknown model names fail the plan",
+			},
+			"bulk_concurrency": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("LOOKER_BULK_CONCURRENCY", defaultBulkConcurrency),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Number of API calls a resource makes at the same time for bulk operations, such as adding the members of a group. Defaults to 8. `max_concurrent_requests` still caps the requests in flight across all resources",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"looker_user":                       resourceUser(),
 			"looker_user_roles":                 resourceUserRoles(),
+			"looker_user_role":                  resourceUserRole(),
+			"looker_user_api_credentials":       resourceUserAPICredentials(),
 			"looker_permission_set":             resourcePermissionSet(),
 			"looker_model_set":                  resourceModelSet(),
 			"looker_group":                      resourceGroup(),
 			"looker_group_membership":           resourceGroupMembership(),
+			"looker_group_user":                 resourceGroupUser(),
+			"looker_group_group":                resourceGroupGroup(),
 			"looker_role":                       resourceRole(),
 			"looker_role_groups":                resourceRoleGroups(),
+			"looker_role_group":                 resourceRoleGroup(),
 			"looker_user_attribute":             resourceUserAttribute(),
 			"looker_user_attribute_user_value":  resourceUserAttributeUserValue(),
 			"looker_user_attribute_group_value": resourceUserAttributeGroupValue(),
 			"looker_connection":                 resourceConnection(),
 			"looker_lookml_model":               resourceLookMLModel(),
+			"looker_project":                    resourceProject(),
+			"looker_project_git_deploy_key":     resourceProjectGitDeployKey(),
+			"looker_project_deploy":             resourceProjectDeploy(),
 			"looker_service_account":            resourceServiceAccount(),
 			"looker_folder":                     resourceFolder(),
+			"looker_dashboard":                  resourceDashboard(),
+			"looker_look":                       resourceLook(),
+			"looker_scheduled_plan":             resourceScheduledPlan(),
 			"looker_content_metadata_access":    resourceContentMetadataAccess(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
@@ -77,22 +222,196 @@ func Provider() *schema.Provider {
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	baseUrl := d.Get("base_url").(string)
-	clientID := d.Get("client_id").(string)
-	clientSecret := d.Get("client_secret").(string)
-	apiVersion := d.Get("api_version").(string)
-	timeout := d.Get("timeout").(int)
+	apiSettings, diags := expandAPISettings(d)
+	if diags.HasError() {
+		return nil, diags
+	}
 
-	apiSettings := rtl.ApiSettings{
-		BaseUrl:      baseUrl,
-		ClientId:     clientID,
-		ClientSecret: clientSecret,
-		ApiVersion:   apiVersion,
-		VerifySsl:    d.Get("verify_ssl").(bool),
-		Timeout:      int32(timeout),
+	retryWaitMin := time.Duration(d.Get("retry_wait_min").(int)) * time.Second
+	retryWaitMax := time.Duration(d.Get("retry_wait_max").(int)) * time.Second
+	if retryWaitMax < retryWaitMin {
+		return nil, diag.Errorf("retry_wait_max (%s) must not be less than retry_wait_min (%s)", retryWaitMax, retryWaitMin)
+	}
+	retryableStatusCodes := defaultRetryableStatusCodes
+	if v, ok := d.GetOk("retryable_status_codes"); ok {
+		retryableStatusCodes = expandIntListFromSet(v)
+	}
+
+	// Every LookerSDK call, including the OAuth login, goes through these transports.
+	// Retries sit on top of the limiter so that every attempt is throttled as well,
+	// and every attempt that is actually sent is logged.
+	baseTransport, err := expandBaseTransport(d, apiSettings.VerifySsl)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	transport := &retryTransport{
+		base:                 newLimitTransport(newLogTransport(ctx, baseTransport), d.Get("max_requests_per_second").(float64), d.Get("max_concurrent_requests").(int)),
+		maxRetries:           d.Get("max_retries").(int),
+		waitMin:              retryWaitMin,
+		waitMax:              retryWaitMax,
+		retryableStatusCodes: retryableStatusCodes,
+	}
+
+	tokenSource, diags := newTokenSource(d, apiSettings, transport)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	client := newLookerClient(apiSettings, tokenSource, transport, d.Get("sudo_as_user_id").(string))
+	client.bulkConcurrency = d.Get("bulk_concurrency").(int)
+	client.allowMissingModels = d.Get("allow_missing_models").(bool)
+	if apiSettings.ClientId != "" {
+		client.newSession = func() oauth2.TokenSource {
+			return newClientCredentialsTokenSource(apiSettings, transport)
+		}
 	}
-	authSession := rtl.NewAuthSession(apiSettings)
-	client := apiclient.NewLookerSDK(authSession)
 
 	return client, diag.Diagnostics{}
 }
+
+// expandAPISettings builds the API settings from the provider arguments, falling back to the
+// section of config_file for the ones that are neither configured nor set in the environment.
+func expandAPISettings(d *schema.ResourceData) (rtl.ApiSettings, diag.Diagnostics) {
+	apiSettings := rtl.ApiSettings{
+		ApiVersion: d.Get("api_version").(string),
+		VerifySsl:  true,
+	}
+
+	if configFile, ok := d.GetOk("config_file"); ok {
+		section := d.Get("config_section").(string)
+		fileSettings, err := readConfigSection(configFile.(string), section)
+		if err != nil {
+			return apiSettings, diag.Errorf("failed to read section %q of config_file %s: %s", section, configFile, err)
+		}
+		apiSettings.BaseUrl = fileSettings.BaseUrl
+		apiSettings.VerifySsl = fileSettings.VerifySsl
+		apiSettings.Timeout = fileSettings.Timeout
+
+		// credentials in the file are ignored when another authentication mode is configured
+		if d.Get("access_token").(string) == "" && d.Get("access_token_file").(string) == "" {
+			apiSettings.ClientId = fileSettings.ClientId
+			apiSettings.ClientSecret = fileSettings.ClientSecret
+		}
+	}
+
+	if v, ok := d.GetOk("base_url"); ok {
+		apiSettings.BaseUrl = v.(string)
+	}
+	if v, ok := d.GetOk("client_id"); ok {
+		apiSettings.ClientId = v.(string)
+	}
+	if v, ok := d.GetOk("client_secret"); ok {
+		apiSettings.ClientSecret = v.(string)
+	}
+	// GetOk cannot tell an explicit false from an unset bool
+	if v, ok := d.GetOkExists("verify_ssl"); ok { //nolint:staticcheck
+		apiSettings.VerifySsl = v.(bool)
+	}
+	if v, ok := d.GetOk("timeout"); ok {
+		apiSettings.Timeout = int32(v.(int))
+	}
+
+	if apiSettings.BaseUrl == "" {
+		return apiSettings, diag.Errorf("base_url must be configured, either as an argument, through LOOKER_API_BASE_URL or in config_file")
+	}
+	return apiSettings, nil
+}
+
+// readConfigSection reads the API settings of a section of a looker.ini file.
+// rtl.NewSettingsFromFile reads a missing section as an empty one, so its existence is checked first.
+func readConfigSection(path, section string) (rtl.ApiSettings, error) {
+	cfg, err := ini.Load(path)
+	if err != nil {
+		return rtl.ApiSettings{}, err
+	}
+	if _, err := cfg.GetSection(section); err != nil {
+		return rtl.ApiSettings{}, errors.New("section not found")
+	}
+	return rtl.NewSettingsFromFile(path, &section)
+}
+
+// expandBaseTransport builds the HTTP transport with the TLS and proxy settings the Looker instance is reached with.
+func expandBaseTransport(d *schema.ResourceData, verifySSL bool) (*http.Transport, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: !verifySSL,
+	}
+
+	caCertPEM := []byte(d.Get("ca_cert_pem").(string))
+	if caCertFile, ok := d.GetOk("ca_cert_file"); ok {
+		content, err := os.ReadFile(caCertFile.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ca_cert_file: %w", err)
+		}
+		caCertPEM = content
+	}
+	if len(caCertPEM) > 0 {
+		// trust the additional certificates on top of the system ones
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCertPEM) {
+			return nil, errors.New("no valid PEM-encoded certificate found in CA certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert, ok := d.GetOk("client_cert"); ok {
+		certificate, err := tls.X509KeyPair([]byte(clientCert.(string)), []byte(d.Get("client_key").(string)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client_cert and client_key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+	if proxyURL, ok := d.GetOk("proxy_url"); ok {
+		parsed, err := url.Parse(proxyURL.(string))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(parsed)
+	}
+	return transport, nil
+}
+
+// newTokenSource returns the token source for the single authentication mode configured:
+// API client credentials, a pre-issued access token or an access token file.
+func newTokenSource(d *schema.ResourceData, apiSettings rtl.ApiSettings, transport http.RoundTripper) (oauth2.TokenSource, diag.Diagnostics) {
+	accessToken := d.Get("access_token").(string)
+	accessTokenFile := d.Get("access_token_file").(string)
+	useClientCredentials := apiSettings.ClientId != "" || apiSettings.ClientSecret != ""
+
+	modes := 0
+	for _, configured := range []bool{useClientCredentials, accessToken != "", accessTokenFile != ""} {
+		if configured {
+			modes++
+		}
+	}
+	if modes != 1 {
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "Invalid authentication configuration",
+			Detail:   "Exactly one of client_id and client_secret, access_token or access_token_file must be configured.",
+		}}
+	}
+
+	switch {
+	case accessToken != "":
+		return newAccessTokenSource(accessToken), nil
+	case accessTokenFile != "":
+		source := newFileTokenSource(accessTokenFile)
+		// fail early instead of on the first API call
+		if _, err := source.Token(); err != nil {
+			return nil, diag.FromErr(err)
+		}
+		return source, nil
+	default:
+		if apiSettings.ClientId == "" || apiSettings.ClientSecret == "" {
+			return nil, diag.Errorf("client_id and client_secret must be configured together")
+		}
+		return newClientCredentialsTokenSource(apiSettings, transport), nil
+	}
+}
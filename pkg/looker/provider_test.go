@@ -1,10 +1,29 @@
 package looker
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -19,6 +38,74 @@ func init() {
 	}
 }
 
+// TestMain points the acceptance tests at an in-process fake Looker API when no real
+// instance is configured, so `TF_ACC=1 go test` works without network access.
+func TestMain(m *testing.M) {
+	if os.Getenv(resource.EnvTfAcc) == "" || os.Getenv("LOOKER_API_BASE_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	srv := fakelooker.NewServer()
+	env := map[string]string{
+		"LOOKER_API_BASE_URL":      srv.URL,
+		"LOOKER_API_CLIENT_ID":     fakelooker.ClientID,
+		"LOOKER_API_CLIENT_SECRET": fakelooker.ClientSecret,
+		// the fixtures reference a model named "test", which real instances used for the acceptance tests
+		// must have, but the fake doesn't
+		"LOOKER_ALLOW_MISSING_MODELS": "true",
+	}
+	for key, value := range env {
+		if err := os.Setenv(key, value); err != nil {
+			panic(err)
+		}
+	}
+
+	code := m.Run()
+	srv.Close()
+	os.Exit(code)
+}
+
+// newTestClient starts a fake Looker instance, closed when the test ends, and returns it with a client
+// authenticated as its admin.
+func newTestClient(t *testing.T) (*fakelooker.Server, *lookerClient) {
+	t.Helper()
+	return newTestClientWithTransport(t, http.DefaultTransport)
+}
+
+// newTestClientWithTransport is newTestClient with the client sending its requests through transport.
+func newTestClientWithTransport(t *testing.T, transport http.RoundTripper) (*fakelooker.Server, *lookerClient) {
+	t.Helper()
+	srv := fakelooker.NewServer()
+	t.Cleanup(srv.Close)
+
+	settings := rtl.ApiSettings{BaseUrl: srv.URL, ApiVersion: defaultAPIVersion}
+	return srv, newLookerClient(settings, newAccessTokenSource(srv.IssueToken(fakelooker.AdminUserID)), transport, "")
+}
+
+// testDiff plans the config of the resource against its state, like terraform plan.
+func testDiff(t *testing.T, r *schema.Resource, client *lookerClient, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceDiff {
+	t.Helper()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client)
+	require.NoError(t, err)
+	return diff
+}
+
+// testApply plans and applies the config of the resource, like terraform apply, and fails the test on errors.
+func testApply(t *testing.T, r *schema.Resource, client *lookerClient, state *terraform.InstanceState, config map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	state, diags := r.Apply(context.Background(), state, testDiff(t, r, client, state, config), client)
+	require.False(t, diags.HasError(), "%v", diags)
+	return state
+}
+
+// testRefresh reads the resource, like terraform refresh, and fails the test on errors.
+func testRefresh(t *testing.T, r *schema.Resource, client *lookerClient, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+	state, diags := r.RefreshWithoutUpgrade(context.Background(), state, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	return state
+}
+
 func TestProvider(t *testing.T) {
 	if err := Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
@@ -26,9 +113,15 @@ func TestProvider(t *testing.T) {
 }
 
 func testAccPreCheck(t *testing.T) {
+	if os.Getenv("LOOKER_CONFIG_FILE") != "" {
+		return
+	}
 	if err := os.Getenv("LOOKER_API_BASE_URL"); err == "" {
 		t.Fatal("LOOKER_API_BASE_URL must be set for acceptance tests")
 	}
+	if os.Getenv("LOOKER_ACCESS_TOKEN") != "" || os.Getenv("LOOKER_ACCESS_TOKEN_FILE") != "" {
+		return
+	}
 	if err := os.Getenv("LOOKER_API_CLIENT_ID"); err == "" {
 		t.Fatal("LOOKER_API_CLIENT_ID must be set for acceptance tests")
 	}
@@ -36,3 +129,373 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("LOOKER_API_CLIENT_SECRET must be set for acceptance tests")
 	}
 }
+
+func TestProviderConfigure_AuthModes(t *testing.T) {
+	srv := fakelooker.NewServer()
+	defer srv.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte(srv.IssueToken(fakelooker.AdminUserID)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		wantErr bool
+	}{
+		"client credentials": {
+			config: map[string]interface{}{
+				"client_id":     fakelooker.ClientID,
+				"client_secret": fakelooker.ClientSecret,
+			},
+			wantErr: false,
+		},
+		"access token": {
+			config: map[string]interface{}{
+				"access_token": srv.IssueToken(fakelooker.AdminUserID),
+			},
+			wantErr: false,
+		},
+		"access token file": {
+			config: map[string]interface{}{
+				"access_token_file": tokenFile,
+			},
+			wantErr: false,
+		},
+		"no authentication": {
+			config:  map[string]interface{}{},
+			wantErr: true,
+		},
+		"client id without secret": {
+			config: map[string]interface{}{
+				"client_id": fakelooker.ClientID,
+			},
+			wantErr: true,
+		},
+		"client credentials and access token": {
+			config: map[string]interface{}{
+				"client_id":     fakelooker.ClientID,
+				"client_secret": fakelooker.ClientSecret,
+				"access_token":  "token",
+			},
+			wantErr: true,
+		},
+		"missing access token file": {
+			config: map[string]interface{}{
+				"access_token_file": filepath.Join(t.TempDir(), "missing"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, env := range []string{"LOOKER_API_CLIENT_ID", "LOOKER_API_CLIENT_SECRET", "LOOKER_ACCESS_TOKEN", "LOOKER_ACCESS_TOKEN_FILE"} {
+		t.Setenv(env, "")
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			raw := map[string]interface{}{"base_url": srv.URL}
+			for k, v := range tt.config {
+				raw[k] = v
+			}
+
+			p := Provider()
+			diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+			if tt.wantErr {
+				assert.True(t, diags.HasError())
+				return
+			}
+			require.False(t, diags.HasError(), "%v", diags)
+
+			_, err := p.Meta().(*lookerClient).Me("", nil)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestExpandAPISettings_ConfigFile(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "looker.ini")
+	ini := `[Looker]
+base_url=https://prod.example.com:19999
+client_id=prod-id
+client_secret=prod-secret
+verify_ssl=True
+timeout=60
+
+[Staging]
+base_url=https://staging.example.com:19999
+client_id=staging-id
+client_secret=staging-secret
+verify_ssl=False
+`
+	if err := os.WriteFile(configFile, []byte(ini), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		env     map[string]string
+		want    rtl.ApiSettings
+		wantErr string
+	}{
+		"default section": {
+			config: map[string]interface{}{
+				"config_file": configFile,
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:      "https://prod.example.com:19999",
+				ClientId:     "prod-id",
+				ClientSecret: "prod-secret",
+				ApiVersion:   defaultAPIVersion,
+				VerifySsl:    true,
+				Timeout:      60,
+			},
+		},
+		"named section": {
+			config: map[string]interface{}{
+				"config_file":    configFile,
+				"config_section": "Staging",
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:      "https://staging.example.com:19999",
+				ClientId:     "staging-id",
+				ClientSecret: "staging-secret",
+				ApiVersion:   defaultAPIVersion,
+				VerifySsl:    false,
+				Timeout:      120,
+			},
+		},
+		"arguments take precedence": {
+			config: map[string]interface{}{
+				"config_file":    configFile,
+				"config_section": "Staging",
+				"base_url":       "https://override.example.com",
+				"client_id":      "override-id",
+				"verify_ssl":     true,
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:      "https://override.example.com",
+				ClientId:     "override-id",
+				ClientSecret: "staging-secret",
+				ApiVersion:   defaultAPIVersion,
+				VerifySsl:    true,
+				Timeout:      120,
+			},
+		},
+		"environment variables take precedence": {
+			config: map[string]interface{}{
+				"config_file": configFile,
+			},
+			env: map[string]string{
+				"LOOKER_API_BASE_URL": "https://env.example.com",
+				"LOOKER_TIMEOUT":      "30",
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:      "https://env.example.com",
+				ClientId:     "prod-id",
+				ClientSecret: "prod-secret",
+				ApiVersion:   defaultAPIVersion,
+				VerifySsl:    true,
+				Timeout:      30,
+			},
+		},
+		"credentials in the file are ignored with an access token": {
+			config: map[string]interface{}{
+				"config_file":  configFile,
+				"access_token": "token",
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:    "https://prod.example.com:19999",
+				ApiVersion: defaultAPIVersion,
+				VerifySsl:  true,
+				Timeout:    60,
+			},
+		},
+		"no config file": {
+			config: map[string]interface{}{
+				"base_url": "https://example.com",
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:    "https://example.com",
+				ApiVersion: defaultAPIVersion,
+				VerifySsl:  true,
+			},
+		},
+		"verify_ssl disabled without config file": {
+			config: map[string]interface{}{
+				"base_url":   "https://example.com",
+				"verify_ssl": false,
+			},
+			want: rtl.ApiSettings{
+				BaseUrl:    "https://example.com",
+				ApiVersion: defaultAPIVersion,
+				VerifySsl:  false,
+			},
+		},
+		"missing section": {
+			config: map[string]interface{}{
+				"config_file":    configFile,
+				"config_section": "Dev",
+				"base_url":       "https://example.com",
+			},
+			wantErr: `failed to read section "Dev"`,
+		},
+		"missing file": {
+			config: map[string]interface{}{
+				"config_file": filepath.Join(t.TempDir(), "missing.ini"),
+			},
+			wantErr: `failed to read section "Looker"`,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			for _, env := range []string{"LOOKER_API_BASE_URL", "LOOKER_API_CLIENT_ID", "LOOKER_API_CLIENT_SECRET", "LOOKER_VERIFY_SSL", "LOOKER_TIMEOUT", "LOOKER_ACCESS_TOKEN", "LOOKER_ACCESS_TOKEN_FILE", "LOOKER_CONFIG_FILE", "LOOKER_CONFIG_SECTION"} {
+				t.Setenv(env, tt.env[env])
+			}
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tt.config)
+			actual, diags := expandAPISettings(d)
+			if tt.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, tt.wantErr)
+				return
+			}
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, tt.want, actual)
+		})
+	}
+}
+
+func TestExpandBaseTransport(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+	}))
+	defer proxy.Close()
+
+	var presentedClientCert bool
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presentedClientCert = len(r.TLS.PeerCertificates) > 0
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	caCertPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}))
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte(caCertPEM), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	clientCert, clientKey := generateClientCertificate(t)
+
+	tests := map[string]struct {
+		config         map[string]interface{}
+		url            string
+		wantErr        bool
+		wantRequestErr bool
+		wantClientCert bool
+	}{
+		"untrusted certificate": {
+			config:         map[string]interface{}{},
+			url:            srv.URL,
+			wantRequestErr: true,
+		},
+		"ca_cert_pem": {
+			config: map[string]interface{}{
+				"ca_cert_pem": caCertPEM,
+			},
+			url: srv.URL,
+		},
+		"ca_cert_file": {
+			config: map[string]interface{}{
+				"ca_cert_file": caCertFile,
+			},
+			url: srv.URL,
+		},
+		"client certificate": {
+			config: map[string]interface{}{
+				"ca_cert_pem": caCertPEM,
+				"client_cert": clientCert,
+				"client_key":  clientKey,
+			},
+			url:            srv.URL,
+			wantClientCert: true,
+		},
+		"proxy_url": {
+			config: map[string]interface{}{
+				"proxy_url": proxy.URL,
+			},
+			url: "http://looker.example.com/api/4.0/user",
+		},
+		"invalid ca_cert_pem": {
+			config: map[string]interface{}{
+				"ca_cert_pem": "not a certificate",
+			},
+			wantErr: true,
+		},
+		"missing ca_cert_file": {
+			config: map[string]interface{}{
+				"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem"),
+			},
+			wantErr: true,
+		},
+		"invalid client certificate": {
+			config: map[string]interface{}{
+				"client_cert": clientCert,
+				"client_key":  "not a key",
+			},
+			wantErr: true,
+		},
+	}
+
+	t.Setenv("LOOKER_CA_CERT_FILE", "")
+	t.Setenv("LOOKER_PROXY_URL", "")
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, tt.config)
+			transport, err := expandBaseTransport(d, true)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			resp, err := (&http.Client{Transport: transport}).Get(tt.url)
+			if tt.wantRequestErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			if tt.wantClientCert {
+				assert.True(t, presentedClientCert)
+			}
+		})
+	}
+
+	assert.Equal(t, "looker.example.com", proxiedHost)
+}
+
+func generateClientCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
@@ -244,7 +244,7 @@ func resourceConnection() *schema.Resource {
 }
 
 func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	body := expandWriteDBConnection(d)
 
@@ -259,12 +259,12 @@ func resourceConnectionCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 	connectionID := d.Id()
 
 	connection, err := client.Connection(connectionID, "", nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -275,7 +275,7 @@ func resourceConnectionRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	name := d.Id()
 	body := expandWriteDBConnection(d)
@@ -289,13 +289,16 @@ func resourceConnectionUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	connectionID := d.Id()
 	name := d.Get("name").(string)
 
 	_, err := client.DeleteConnection(connectionID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteConnection", "connection", "name=%s, id=%s", name, connectionID))
 	}
 
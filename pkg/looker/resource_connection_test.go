@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_Connection(t *testing.T) {
@@ -46,7 +45,7 @@ func testAccCheckConnectionExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("no connection setting ID is set")
 		}
 
-		client := testAccProvider.Meta().(*apiclient.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 		connectionName := rs.Primary.ID
 
 		_, err := client.Connection(connectionName, "", nil)
@@ -59,7 +58,7 @@ func testAccCheckConnectionExists(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckConnectionDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_connection" {
@@ -69,7 +68,7 @@ func testAccCheckConnectionDestroy(s *terraform.State) error {
 		connectionName := rs.Primary.ID
 		_, err := client.Connection(connectionName, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
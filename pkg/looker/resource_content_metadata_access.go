@@ -59,12 +59,13 @@ func resourceContentMetadataAccess() *schema.Resource {
 				ConflictsWith: []string{"user_id"},
 				AtLeastOneOf:  []string{"user_id", "group_id"},
 			},
+			"sudo_as_user_id": sudoAsUserIDSchema(),
 		},
 	}
 }
 
 func resourceContentMetadataAccessCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	contentMetadataID := d.Get("content_metadata_id").(string)
 	permissionType := apiclient.PermissionType(d.Get("permission_type").(string))
@@ -98,14 +99,14 @@ func resourceContentMetadataAccessCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceContentMetadataAccessRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	accessID := d.Id()
 	contentMetadataID := d.Get("content_metadata_id").(string)
 
 	accesses, err := client.AllContentMetadataAccesses(contentMetadataID, "", nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -163,7 +164,7 @@ func resourceContentMetadataAccessRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceContentMetadataAccessUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	accessID := d.Id()
 
@@ -183,13 +184,13 @@ func resourceContentMetadataAccessUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceContentMetadataAccessDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	accessID := d.Id()
 
 	_, err := client.DeleteContentMetadataAccess(accessID, nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(wrapSDKError(err, "DeleteContentMetadataAccess", "content_metadata_access", "id=%s", accessID))
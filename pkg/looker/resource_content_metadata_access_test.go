@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_ContentMetadataAccess_Group(t *testing.T) {
@@ -102,7 +101,7 @@ func contentMetadataAccessImportStateIdFunc(resourceName string) resource.Import
 }
 
 func testAccCheckContentMetadataAccessDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_content_metadata_access" {
@@ -114,7 +113,7 @@ func testAccCheckContentMetadataAccessDestroy(s *terraform.State) error {
 
 		accesses, err := client.AllContentMetadataAccesses(contentMetadataID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				continue
 			}
 			return err
@@ -0,0 +1,468 @@
+package looker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceDashboard() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a user-defined dashboard.\n\n" +
+			"The dashboard is either built from its attributes and `filter` blocks, optionally starting from a `dashboard_json` body, " +
+			"or imported from the LookML in `lookml`. Changing `lookml` replaces the dashboard.",
+		CreateContext: resourceDashboardCreate,
+		ReadContext:   resourceDashboardRead,
+		UpdateContext: resourceDashboardUpdate,
+		DeleteContext: resourceDashboardDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Title of the dashboard. Required unless the dashboard is imported from `lookml`.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Description of the dashboard.",
+			},
+			"folder_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "ID of the folder the dashboard is stored in.",
+			},
+			"refresh_interval": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Interval the dashboard refreshes at, as a duration phrase like `2 hours 30 minutes`.",
+			},
+			"filter": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"lookml"},
+				Description:   "Filters of the dashboard, in the order they are displayed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the filter, unique within the dashboard.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Title of the filter.",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"date", "number", "string", "field"}, false),
+							Description:  "Type of the filter: `date`, `number`, `string` or `field`.",
+						},
+						"default_value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Default value of the filter.",
+						},
+						"model": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Model of the filter, required for the `field` type.",
+						},
+						"explore": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Explore of the filter, required for the `field` type.",
+						},
+						"dimension": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Dimension of the filter, required for the `field` type.",
+						},
+						"allow_multiple_values": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the filter accepts multiple values.",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the filter needs a value to run the dashboard.",
+						},
+					},
+				},
+			},
+			"lookml": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"dashboard_json"},
+				Description:   "LookML of the dashboard, imported with the Looker API. Looker does not link the dashboard to the LookML, so later changes to the dashboard are not detected against it.",
+			},
+			"dashboard_json": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateDashboardJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description: "JSON object with the writable properties of the dashboard in the Looker API, such as `show_title` or `query_timezone`, " +
+					"except the ones that are attributes of this resource. Only the properties it sets are checked for drift.",
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the content metadata of the dashboard, to manage its access with looker_content_metadata_access.",
+			},
+			"slug": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Slug of the dashboard.",
+			},
+			"sudo_as_user_id": sudoAsUserIDSchema(),
+		},
+	}
+}
+
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+	folderID := d.Get("folder_id").(string)
+
+	if lookml, ok := d.GetOk("lookml"); ok {
+		body := apiclient.WriteDashboardLookml{
+			FolderId: &folderID,
+			Lookml:   ptrTo(lookml.(string)),
+		}
+		dashboard, err := client.ImportDashboardFromLookml(body, nil)
+		if err != nil {
+			return diag.FromErr(wrapSDKError(err, "ImportDashboardFromLookml", "dashboard", "folder_id=%s", folderID))
+		}
+		d.SetId(*dashboard.Id)
+
+		// the attributes set next to the LookML take precedence over it
+		writeDashboard, err := expandWriteDashboard(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if writeDashboard.Title != nil || writeDashboard.Description != nil || writeDashboard.RefreshInterval != nil {
+			_, err = client.UpdateDashboard(d.Id(), writeDashboard, nil)
+			if err != nil {
+				return diag.FromErr(wrapSDKError(err, "UpdateDashboard", "dashboard", "%s", d.Id()))
+			}
+		}
+
+		return resourceDashboardRead(ctx, d, m)
+	}
+
+	writeDashboard, err := expandWriteDashboard(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	dashboard, err := client.CreateDashboard(writeDashboard, nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "CreateDashboard", "dashboard", "%s", valueOrZero(writeDashboard.Title)))
+	}
+	d.SetId(*dashboard.Id)
+
+	if err = syncDashboardFilters(client, d.Id(), d.Get("filter").([]interface{})); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDashboardRead(ctx, d, m)
+}
+
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	dashboardID := d.Id()
+
+	dashboard, err := client.Dashboard(dashboardID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "Dashboard", "dashboard", "%s", dashboardID))
+	}
+	if valueOrZero(dashboard.Deleted) {
+		// the dashboard was moved to the trash outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("title", dashboard.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", dashboard.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("folder_id", dashboard.FolderId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("refresh_interval", dashboard.RefreshInterval); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("content_metadata_id", dashboard.ContentMetadataId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("slug", dashboard.Slug); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the filters of a dashboard imported from LookML are managed by the LookML
+	if d.Get("lookml").(string) == "" {
+		if err = d.Set("filter", flattenDashboardFilters(valueOrZero(dashboard.DashboardFilters))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if configured := d.Get("dashboard_json").(string); configured != "" {
+		dashboardJSON, err := normalizeDashboardJSON(configured, dashboard)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = d.Set("dashboard_json", dashboardJSON); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	dashboardID := d.Id()
+
+	if d.HasChanges("title", "description", "folder_id", "refresh_interval", "dashboard_json") {
+		writeDashboard, err := expandWriteDashboard(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_, err = client.UpdateDashboard(dashboardID, writeDashboard, nil)
+		if err != nil {
+			return diag.FromErr(wrapSDKError(err, "UpdateDashboard", "dashboard", "%s", dashboardID))
+		}
+	}
+
+	if d.HasChange("filter") {
+		if err := syncDashboardFilters(client, dashboardID, d.Get("filter").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceDashboardRead(ctx, d, m)
+}
+
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	dashboardID := d.Id()
+
+	_, err := client.DeleteDashboard(dashboardID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteDashboard", "dashboard", "%s", dashboardID))
+	}
+
+	return nil
+}
+
+// expandWriteDashboard builds the dashboard from dashboard_json and the attributes.
+func expandWriteDashboard(d *schema.ResourceData) (apiclient.WriteDashboard, error) {
+	var writeDashboard apiclient.WriteDashboard
+	if v, ok := d.GetOk("dashboard_json"); ok {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(v.(string))))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&writeDashboard); err != nil {
+			return writeDashboard, fmt.Errorf("dashboard_json must be a JSON object of writable dashboard properties: %w", err)
+		}
+	}
+
+	writeDashboard.FolderId = ptrTo(d.Get("folder_id").(string))
+	if v, ok := d.GetOk("title"); ok {
+		writeDashboard.Title = ptrTo(v.(string))
+	}
+	if v, ok := d.GetOk("description"); ok {
+		writeDashboard.Description = ptrTo(v.(string))
+	}
+	if v, ok := d.GetOk("refresh_interval"); ok {
+		writeDashboard.RefreshInterval = ptrTo(v.(string))
+	}
+	return writeDashboard, nil
+}
+
+// dashboardAttributeProperties are the dashboard properties managed by attributes, which dashboard_json must not set.
+var dashboardAttributeProperties = []string{"title", "description", "folder_id", "refresh_interval"}
+
+// validateDashboardJSON checks that dashboard_json is a JSON object that doesn't set the properties managed by attributes.
+func validateDashboardJSON(i interface{}, k string) ([]string, []error) {
+	var properties map[string]interface{}
+	if err := json.Unmarshal([]byte(i.(string)), &properties); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON object: %w", k, err)}
+	}
+	var errs []error
+	for _, property := range dashboardAttributeProperties {
+		if _, ok := properties[property]; ok {
+			errs = append(errs, fmt.Errorf("%q must not set %q, use the %s attribute instead", k, property, property))
+		}
+	}
+	return nil, errs
+}
+
+// syncDashboardFilters makes the filters of the dashboard match the filter blocks, matching them by name.
+func syncDashboardFilters(client *apiclient.LookerSDK, dashboardID string, filters []interface{}) error {
+	current, err := client.DashboardDashboardFilters(dashboardID, "", nil)
+	if err != nil {
+		return wrapSDKError(err, "DashboardDashboardFilters", "dashboard", "%s", dashboardID)
+	}
+	currentIDs := map[string]string{}
+	for _, filter := range current {
+		currentIDs[valueOrZero(filter.Name)] = valueOrZero(filter.Id)
+	}
+
+	wanted := map[string]bool{}
+	for _, filter := range filters {
+		wanted[filter.(map[string]interface{})["name"].(string)] = true
+	}
+	for name, filterID := range currentIDs {
+		if wanted[name] {
+			continue
+		}
+		if _, err = client.DeleteDashboardFilter(filterID, nil); err != nil && !isNotFound(err) {
+			return wrapSDKError(err, "DeleteDashboardFilter", "dashboard", "%s:%s", dashboardID, name)
+		}
+	}
+
+	for i, v := range filters {
+		filter := expandDashboardFilter(v.(map[string]interface{}), int64(i))
+		if filterID, ok := currentIDs[*filter.Name]; ok {
+			if _, err = client.UpdateDashboardFilter(filterID, filter, "", nil); err != nil {
+				return wrapSDKError(err, "UpdateDashboardFilter", "dashboard", "%s:%s", dashboardID, *filter.Name)
+			}
+			continue
+		}
+
+		body := apiclient.WriteCreateDashboardFilter{
+			DashboardId:         dashboardID,
+			Name:                *filter.Name,
+			Title:               *filter.Title,
+			Type:                *filter.Type,
+			DefaultValue:        filter.DefaultValue,
+			Model:               filter.Model,
+			Explore:             filter.Explore,
+			Dimension:           filter.Dimension,
+			Row:                 filter.Row,
+			AllowMultipleValues: filter.AllowMultipleValues,
+			Required:            filter.Required,
+		}
+		if _, err = client.CreateDashboardFilter(body, "", nil); err != nil {
+			return wrapSDKError(err, "CreateDashboardFilter", "dashboard", "%s:%s", dashboardID, *filter.Name)
+		}
+	}
+
+	return nil
+}
+
+func expandDashboardFilter(filter map[string]interface{}, row int64) apiclient.WriteDashboardFilter {
+	writeFilter := apiclient.WriteDashboardFilter{
+		Name:                ptrTo(filter["name"].(string)),
+		Title:               ptrTo(filter["title"].(string)),
+		Type:                ptrTo(filter["type"].(string)),
+		DefaultValue:        ptrTo(filter["default_value"].(string)),
+		Row:                 &row,
+		AllowMultipleValues: ptrTo(filter["allow_multiple_values"].(bool)),
+		Required:            ptrTo(filter["required"].(bool)),
+	}
+	if v := filter["model"].(string); v != "" {
+		writeFilter.Model = &v
+	}
+	if v := filter["explore"].(string); v != "" {
+		writeFilter.Explore = &v
+	}
+	if v := filter["dimension"].(string); v != "" {
+		writeFilter.Dimension = &v
+	}
+	return writeFilter
+}
+
+func flattenDashboardFilters(filters []apiclient.DashboardFilter) []interface{} {
+	flattened := make([]interface{}, 0, len(filters))
+	for _, filter := range filters {
+		flattened = append(flattened, map[string]interface{}{
+			"name":                  valueOrZero(filter.Name),
+			"title":                 valueOrZero(filter.Title),
+			"type":                  valueOrZero(filter.Type),
+			"default_value":         valueOrZero(filter.DefaultValue),
+			"model":                 valueOrZero(filter.Model),
+			"explore":               valueOrZero(filter.Explore),
+			"dimension":             valueOrZero(filter.Dimension),
+			"allow_multiple_values": valueOrZero(filter.AllowMultipleValues),
+			"required":              valueOrZero(filter.Required),
+		})
+	}
+	return flattened
+}
+
+// normalizeDashboardJSON returns the properties of the dashboard that the configured dashboard_json sets,
+// so that the volatile properties Looker maintains itself, such as view counts and timestamps, don't show up as drift.
+// Properties Looker doesn't return are kept as configured.
+func normalizeDashboardJSON(configured string, dashboard apiclient.Dashboard) (string, error) {
+	var config map[string]interface{}
+	if err := json.Unmarshal([]byte(configured), &config); err != nil {
+		return "", fmt.Errorf("dashboard_json must be a JSON object: %w", err)
+	}
+
+	b, err := json.Marshal(dashboard)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode dashboard: %w", err)
+	}
+	var actual map[string]interface{}
+	if err = json.Unmarshal(b, &actual); err != nil {
+		return "", fmt.Errorf("failed to decode dashboard: %w", err)
+	}
+
+	b, err = json.Marshal(projectJSON(config, actual))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode dashboard_json: %w", err)
+	}
+	return string(b), nil
+}
+
+// projectJSON returns the values of actual at the keys of config, recursing into nested objects.
+func projectJSON(config, actual map[string]interface{}) map[string]interface{} {
+	projected := make(map[string]interface{}, len(config))
+	for key, configValue := range config {
+		actualValue, ok := actual[key]
+		if !ok {
+			projected[key] = configValue
+			continue
+		}
+		configObject, configIsObject := configValue.(map[string]interface{})
+		actualObject, actualIsObject := actualValue.(map[string]interface{})
+		if configIsObject && actualIsObject {
+			projected[key] = projectJSON(configObject, actualObject)
+			continue
+		}
+		projected[key] = actualValue
+	}
+	return projected
+}
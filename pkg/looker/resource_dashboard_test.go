@@ -0,0 +1,312 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_Dashboard(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: dashboardConfig(name, "1 hour", "country"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_dashboard.test", "title", name),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "refresh_interval", "1 hour"),
+					resource.TestCheckResourceAttrPair("looker_dashboard.test", "folder_id", "looker_folder.test", "id"),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "filter.#", "2"),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "filter.0.name", "date"),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "filter.1.name", "country"),
+					resource.TestCheckResourceAttrSet("looker_dashboard.test", "content_metadata_id"),
+				),
+			},
+			// Test: Update
+			{
+				Config: dashboardConfig(name+"_UPDATED", "30 minutes", "region"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_dashboard.test", "title", name+"_UPDATED"),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "refresh_interval", "30 minutes"),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "filter.1.name", "region"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:            "looker_dashboard.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"dashboard_json"},
+			},
+		},
+		CheckDestroy: testAccCheckDashboardDestroy,
+	})
+}
+
+func TestAcc_DashboardFromLookml(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: dashboardLookmlConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_dashboard.test", "title", name),
+					resource.TestCheckResourceAttr("looker_dashboard.test", "description", "Imported from LookML"),
+					resource.TestCheckResourceAttrPair("looker_dashboard.test", "folder_id", "looker_folder.test", "id"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckDashboardDestroy,
+	})
+}
+
+func TestResourceDashboard(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceDashboard()
+
+	config := map[string]interface{}{
+		"title":          "Sales",
+		"folder_id":      fakelooker.SharedFolderID,
+		"dashboard_json": `{"show_title": false, "query_timezone": "UTC"}`,
+		"filter": []interface{}{
+			map[string]interface{}{"name": "date", "title": "Date", "type": "date", "default_value": "7 days"},
+			map[string]interface{}{"name": "country", "title": "Country", "type": "string"},
+		},
+	}
+	state := testApply(t, r, client, nil, config)
+	dashboardID := state.ID
+
+	dashboard, err := client.Dashboard(dashboardID, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", *dashboard.Title)
+	assert.False(t, *dashboard.ShowTitle)
+	assert.Equal(t, "UTC", *dashboard.QueryTimezone)
+	assert.Equal(t, *dashboard.ContentMetadataId, state.Attributes["content_metadata_id"])
+	require.Len(t, *dashboard.DashboardFilters, 2)
+	assert.Equal(t, "date", *(*dashboard.DashboardFilters)[0].Name)
+
+	// the plan is clean after a refresh
+	state = testRefresh(t, r, client, state)
+	assert.Nil(t, testDiff(t, r, client, state, config))
+
+	// filters are reordered, updated and removed in place
+	config["filter"] = []interface{}{
+		map[string]interface{}{"name": "region", "title": "Region", "type": "string"},
+		map[string]interface{}{"name": "date", "title": "Period", "type": "date", "default_value": "30 days"},
+	}
+	config["dashboard_json"] = `{"show_title": true, "query_timezone": "UTC"}`
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, dashboardID, state.ID)
+	assert.Equal(t, "region", state.Attributes["filter.0.name"])
+	assert.Equal(t, "Period", state.Attributes["filter.1.title"])
+	assert.Equal(t, "30 days", state.Attributes["filter.1.default_value"])
+	filters, err := client.DashboardDashboardFilters(dashboardID, "", nil)
+	require.NoError(t, err)
+	assert.Len(t, filters, 2)
+	assert.Nil(t, testDiff(t, r, client, testRefresh(t, r, client, state), config))
+
+	// changes made outside of Terraform are detected
+	_, err = client.UpdateDashboard(dashboardID, apiclient.WriteDashboard{Title: ptrTo("Renamed"), QueryTimezone: ptrTo("Asia/Tokyo")}, nil)
+	require.NoError(t, err)
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "Renamed", state.Attributes["title"])
+	assert.JSONEq(t, `{"show_title": true, "query_timezone": "Asia/Tokyo"}`, state.Attributes["dashboard_json"])
+	d := testDiff(t, r, client, state, config)
+	require.NotNil(t, d)
+	assert.Contains(t, d.Attributes, "title")
+	assert.Contains(t, d.Attributes, "dashboard_json")
+
+	// a dashboard moved to the trash is removed from the state
+	_, err = client.UpdateDashboard(dashboardID, apiclient.WriteDashboard{Deleted: ptrTo(true)}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, testRefresh(t, r, client, state))
+
+	// the dashboard is deleted for good
+	_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	_, err = client.Dashboard(dashboardID, "", nil)
+	assert.True(t, isNotFound(err))
+}
+
+func TestResourceDashboardFromLookml(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceDashboard()
+	config := map[string]interface{}{
+		"folder_id":   fakelooker.SharedFolderID,
+		"description": "Overridden",
+		"lookml": `- dashboard: sales
+  title: Sales
+  description: From LookML
+  layout: newspaper
+`,
+	}
+	state := testApply(t, r, client, nil, config)
+
+	assert.Equal(t, "Sales", state.Attributes["title"])
+	assert.Equal(t, "Overridden", state.Attributes["description"], "the attributes take precedence over the LookML")
+
+	assert.Nil(t, testDiff(t, r, client, state, config))
+
+	config["lookml"] = strings.Replace(config["lookml"].(string), "title: Sales", "title: Revenue", 1)
+	assert.True(t, testDiff(t, r, client, state, config).RequiresNew())
+}
+
+func TestNormalizeDashboardJSON(t *testing.T) {
+	dashboard := apiclient.Dashboard{
+		Title:         ptrTo("Sales"),
+		ShowTitle:     ptrTo(false),
+		ViewCount:     ptrTo(int64(42)),
+		QueryTimezone: ptrTo("UTC"),
+		Appearance: &apiclient.DashboardAppearance{
+			PageSideMargins: ptrTo(int64(10)),
+			TileShadow:      ptrTo(true),
+		},
+	}
+
+	tests := map[string]struct {
+		configured string
+		want       string
+	}{
+		"only the configured properties are kept": {
+			configured: `{"show_title": true, "query_timezone": "UTC"}`,
+			want:       `{"show_title": false, "query_timezone": "UTC"}`,
+		},
+		"nested objects are narrowed to the configured properties": {
+			configured: `{"appearance": {"tile_shadow": false}}`,
+			want:       `{"appearance": {"tile_shadow": true}}`,
+		},
+		"properties Looker doesn't return are kept as configured": {
+			configured: `{"load_configuration": "cache_run"}`,
+			want:       `{"load_configuration": "cache_run"}`,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			got, err := normalizeDashboardJSON(tt.configured, dashboard)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateDashboardJSON(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		wantErrs []string
+	}{
+		"writable properties": {
+			value: `{"show_title": false, "appearance": {"tile_shadow": true}}`,
+		},
+		"not an object": {
+			value:    `[]`,
+			wantErrs: []string{`"dashboard_json" must be a JSON object: json: cannot unmarshal array into Go value of type map[string]interface {}`},
+		},
+		"properties managed by attributes": {
+			value: `{"title": "Sales", "folder_id": "1"}`,
+			wantErrs: []string{
+				`"dashboard_json" must not set "title", use the title attribute instead`,
+				`"dashboard_json" must not set "folder_id", use the folder_id attribute instead`,
+			},
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			_, errs := validateDashboardJSON(tt.value, "dashboard_json")
+			var got []string
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			assert.Equal(t, tt.wantErrs, got)
+		})
+	}
+}
+
+func testAccCheckDashboardDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_dashboard" {
+			continue
+		}
+
+		_, err := client.Dashboard(rs.Primary.ID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		return fmt.Errorf("dashboard '%s' still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func dashboardConfig(title, refreshInterval, secondFilter string) string {
+	return fmt.Sprintf(`
+	resource "looker_folder" "test" {
+		name      = "%s"
+		parent_id = "1"
+	}
+	resource "looker_dashboard" "test" {
+		title            = "%s"
+		description      = "Managed by Terraform"
+		folder_id        = looker_folder.test.id
+		refresh_interval = "%s"
+		dashboard_json   = jsonencode({
+			show_title     = true
+			query_timezone = "UTC"
+		})
+
+		filter {
+			name          = "date"
+			title         = "Date"
+			type          = "date"
+			default_value = "7 days"
+		}
+		filter {
+			name  = "%s"
+			title = "%s"
+			type  = "string"
+		}
+	}
+	`, title, title, refreshInterval, secondFilter, secondFilter)
+}
+
+func dashboardLookmlConfig(title string) string {
+	return fmt.Sprintf(`
+	resource "looker_folder" "test" {
+		name      = "%s"
+		parent_id = "1"
+	}
+	resource "looker_dashboard" "test" {
+		folder_id = looker_folder.test.id
+		lookml    = <<-EOT
+		- dashboard: %s
+		  title: %s
+		  description: Imported from LookML
+		  layout: newspaper
+		EOT
+	}
+	`, title, strings.ToLower(title), title)
+}
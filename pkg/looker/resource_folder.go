@@ -2,7 +2,6 @@ package looker
 
 import (
 	"context"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -40,12 +39,13 @@ func resourceFolder() *schema.Resource {
 				Default:     true,
 				Description: "Whether content inherits its access levels from parent. Set to false to manage access with looker_content_metadata_access.",
 			},
+			"sudo_as_user_id": sudoAsUserIDSchema(),
 		},
 	}
 }
 
 func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 	folderName := d.Get("name").(string)
 
 	writeFolder := apiclient.CreateFolder{
@@ -81,13 +81,13 @@ func resourceFolderCreate(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	folderID := d.Id()
 
 	folder, err := client.Folder(folderID, "", nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -125,7 +125,7 @@ func resourceFolderRead(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	folderID := d.Id()
 
@@ -168,14 +168,14 @@ func resourceFolderUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceFolderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := clientFor(d, m)
 
 	folderID := d.Id()
 	folderName := d.Get("name").(string)
 
 	_, err := client.DeleteFolder(folderID, nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "404") {
+		if isNotFound(err) {
 			return nil
 		}
 		return diag.FromErr(wrapSDKError(err, "DeleteFolder", "folder", "name=%s, id=%s", folderName, folderID))
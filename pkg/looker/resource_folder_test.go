@@ -9,7 +9,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_Folder(t *testing.T) {
@@ -45,8 +44,46 @@ func TestAcc_Folder(t *testing.T) {
 	})
 }
 
+func TestAcc_FolderSudo(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	email := fmt.Sprintf("%s@example.com", strings.ToLower(name))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: folderSudoConfig(name, email),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_folder.test", "name", name),
+					resource.TestCheckResourceAttrPair("looker_folder.test", "sudo_as_user_id", "looker_user.test", "id"),
+					testAccCheckFolderCreator("looker_folder.test", "looker_user.test"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckFolderDestroy,
+	})
+}
+
+func testAccCheckFolderCreator(folderName, userName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*lookerClient)
+
+		folder, err := client.Folder(s.RootModule().Resources[folderName].Primary.ID, "", nil)
+		if err != nil {
+			return err
+		}
+
+		userID := s.RootModule().Resources[userName].Primary.ID
+		if folder.CreatorId == nil || *folder.CreatorId != userID {
+			return fmt.Errorf("folder was not created by user %s", userID)
+		}
+		return nil
+	}
+}
+
 func testAccCheckFolderDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_folder" {
@@ -57,7 +94,7 @@ func testAccCheckFolderDestroy(s *terraform.State) error {
 
 		_, err := client.Folder(folderID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				continue // successfully destroyed
 			}
 			return err
@@ -115,3 +152,19 @@ func TestAcc_FolderWithoutParentID(t *testing.T) {
 		},
 	})
 }
+
+func folderSudoConfig(name, email string) string {
+	return fmt.Sprintf(`
+	resource "looker_user" "test" {
+		first_name = "%s"
+		last_name  = "%s"
+		email      = "%s"
+	}
+
+	resource "looker_folder" "test" {
+		name            = "%s"
+		parent_id       = "1"
+		sudo_as_user_id = looker_user.test.id
+	}
+	`, name, name, email, name)
+}
@@ -28,7 +28,7 @@ func resourceGroup() *schema.Resource {
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 	groupName := d.Get("name").(string)
 
 	writeGroup := apiclient.WriteGroup{
@@ -47,12 +47,16 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID := d.Id()
 
 	group, err := client.Group(groupID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "Group", "group", "%s", groupID))
 	}
 
@@ -64,7 +68,7 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID := d.Id()
 
@@ -81,13 +85,16 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID := d.Id()
 	groupName := d.Get("name").(string)
 
 	_, err := client.DeleteGroup(groupID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteGroup", "group", "name=%s, id=%s", groupName, groupID))
 	}
 
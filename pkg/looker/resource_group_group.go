@@ -0,0 +1,105 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceGroupGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Includes a single group in a parent group without managing the other members of the parent group. " +
+			"Do not use it together with looker_group_membership for the same parent group, which removes the members it does not manage.",
+		CreateContext: resourceGroupGroupCreate,
+		ReadContext:   resourceGroupGroupRead,
+		DeleteContext: resourceGroupGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"parent_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceGroupGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	parentGroupID := d.Get("parent_group_id").(string)
+	groupID := d.Get("group_id").(string)
+
+	body := apiclient.GroupIdForGroupInclusion{
+		GroupId: &groupID,
+	}
+
+	_, err := client.AddGroupGroup(parentGroupID, body, nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "AddGroupGroup", "group_group", "%s:%s", parentGroupID, groupID))
+	}
+
+	d.SetId(buildTwoPartID(&parentGroupID, &groupID))
+
+	return resourceGroupGroupRead(ctx, d, m)
+}
+
+func resourceGroupGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	parentGroupID, groupID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups, err := client.AllGroupGroups(parentGroupID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "AllGroupGroups", "group_group", "%s:%s", parentGroupID, groupID))
+	}
+	if !contains(flattenGroupIDs(groups), groupID) {
+		// the group was removed from the parent group outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("parent_group_id", parentGroupID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("group_id", groupID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGroupGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	parentGroupID, groupID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteGroupFromGroup(parentGroupID, groupID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteGroupFromGroup", "group_group", "%s:%s", parentGroupID, groupID))
+	}
+
+	return nil
+}
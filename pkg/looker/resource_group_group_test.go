@@ -0,0 +1,116 @@
+package looker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_GroupGroup(t *testing.T) {
+	parent := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	group1 := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	group2 := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: groupGroupConfig(parent, group1, group2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGroupGroupExists("looker_group_group.test1"),
+					testAccCheckGroupGroupExists("looker_group_group.test2"),
+					resource.TestCheckResourceAttrPair("looker_group_group.test1", "parent_group_id", "looker_group.parent", "id"),
+					resource.TestCheckResourceAttrPair("looker_group_group.test1", "group_id", "looker_group.test1", "id"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_group_group.test1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckGroupGroupDestroy,
+	})
+}
+
+func testAccCheckGroupGroupExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("group group not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*lookerClient)
+		parentGroupID, groupID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.AllGroupGroups(parentGroupID, "", nil)
+		if err != nil {
+			return err
+		}
+		if !contains(flattenGroupIDs(groups), groupID) {
+			return fmt.Errorf("group %s is not included in group %s", groupID, parentGroupID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGroupGroupDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_group_group" {
+			continue
+		}
+
+		parentGroupID, groupID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.AllGroupGroups(parentGroupID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		if contains(flattenGroupIDs(groups), groupID) {
+			return fmt.Errorf("group_group '%s' still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func groupGroupConfig(parent, group1, group2 string) string {
+	return fmt.Sprintf(`
+	resource "looker_group" "parent" {
+		name = "%s"
+	}
+	resource "looker_group" "test1" {
+		name = "%s"
+	}
+	resource "looker_group" "test2" {
+		name = "%s"
+	}
+	resource "looker_group_group" "test1" {
+		parent_group_id = looker_group.parent.id
+		group_id        = looker_group.test1.id
+	}
+	resource "looker_group_group" "test2" {
+		parent_group_id = looker_group.parent.id
+		group_id        = looker_group.test2.id
+	}
+	`, parent, group1, group2)
+}
@@ -3,6 +3,7 @@ package looker
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -49,7 +50,7 @@ func resourceGroupMembership() *schema.Resource {
 
 func validate(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
 	userIDs := expandStringListFromSet(d.Get("user_ids"))
-	return checkUsersExist(m, userIDs)
+	return checkUsersExist(ctx, m, userIDs)
 }
 
 func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -57,16 +58,16 @@ func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData,
 
 	// add users
 	userIDs := expandStringListFromSet(d.Get("user_ids"))
-	err := addGroupUsers(m, targetGroupID, userIDs)
+	err := addGroupUsers(ctx, m, targetGroupID, userIDs)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
 	// add groups
 	groupIDs := expandStringListFromSet(d.Get("group_ids"))
-	err = addGroupGroups(m, targetGroupID, groupIDs)
+	err = addGroupGroups(ctx, m, targetGroupID, groupIDs)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
 	d.SetId(targetGroupID)
@@ -75,20 +76,21 @@ func resourceGroupMembershipCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	targetGroupID := d.Get("target_group_id").(string)
 
-	req := apiclient.RequestAllGroupUsers{
-		GroupId: targetGroupID,
-	}
-
-	users, err := client.AllGroupUsers(req, nil) // todo: imeplement paging
+	users, err := allGroupUsers(client.LookerSDK, targetGroupID)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "AllGroupUsers", "group_membership", "%s", targetGroupID))
 	}
 
-	groups, err := client.AllGroupGroups(targetGroupID, "", nil) // todo: imeplement paging
+	// unlike group users, group groups are not paginated
+	groups, err := client.AllGroupGroups(targetGroupID, "", nil)
 	if err != nil {
 		return diag.FromErr(wrapSDKError(err, "AllGroupGroups", "group_membership", "%s", targetGroupID))
 	}
@@ -111,34 +113,46 @@ func resourceGroupMembershipRead(ctx context.Context, d *schema.ResourceData, m
 func resourceGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	targetGroupID := d.Id()
 
-	userIDs := expandStringListFromSet(d.Get("user_ids"))
-	err := checkUsersExist(m, userIDs)
+	// Keep the prior state if the update fails halfway. The next refresh reads back the membership
+	// that was actually applied, so the next apply only retries the part of the delta that is left.
+	d.Partial(true)
+
+	oldUserIDs, newUserIDs := d.GetChange("user_ids")
+	usersToAdd := expandStringListFromSet(newUserIDs.(*schema.Set).Difference(oldUserIDs.(*schema.Set)))
+	usersToRemove := expandStringListFromSet(oldUserIDs.(*schema.Set).Difference(newUserIDs.(*schema.Set)))
+	oldGroupIDs, newGroupIDs := d.GetChange("group_ids")
+	groupsToAdd := expandStringListFromSet(newGroupIDs.(*schema.Set).Difference(oldGroupIDs.(*schema.Set)))
+	groupsToRemove := expandStringListFromSet(oldGroupIDs.(*schema.Set).Difference(newGroupIDs.(*schema.Set)))
+
+	err := checkUsersExist(ctx, m, usersToAdd)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
-	protectedUserIDs := expandStringListFromSet(d.Get("delete_protected_user_ids"))
-	err = removeAllUsersFromGroup(m, targetGroupID, protectedUserIDs)
+	// add before removing, so that members moving from a nested group to the group itself keep their access
+	err = addGroupUsers(ctx, m, targetGroupID, usersToAdd)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
-	err = removeAllGroupsFromGroup(m, targetGroupID)
+	err = addGroupGroups(ctx, m, targetGroupID, groupsToAdd)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
-	err = addGroupUsers(m, targetGroupID, userIDs)
+	protectedUserIDs := expandStringListFromSet(d.Get("delete_protected_user_ids"))
+	err = removeGroupUsers(ctx, m, targetGroupID, usersToRemove, protectedUserIDs)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
-	groupIDs := expandStringListFromSet(d.Get("group_ids"))
-	err = addGroupGroups(m, targetGroupID, groupIDs)
+	err = removeGroupGroups(ctx, m, targetGroupID, groupsToRemove)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
+	d.Partial(false)
+
 	return resourceGroupMembershipRead(ctx, d, m)
 }
 
@@ -147,23 +161,23 @@ func resourceGroupMembershipDelete(ctx context.Context, d *schema.ResourceData,
 
 	protectedUserIDs := expandStringListFromSet(d.Get("delete_protected_user_ids"))
 
-	err := removeAllUsersFromGroup(m, targetGroupID, protectedUserIDs)
+	err := removeAllUsersFromGroup(ctx, m, targetGroupID, protectedUserIDs)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
-	err = removeAllGroupsFromGroup(m, targetGroupID)
+	err = removeAllGroupsFromGroup(ctx, m, targetGroupID)
 	if err != nil {
-		return diag.FromErr(err)
+		return diagsFromErr(err)
 	}
 
 	return resourceGroupMembershipRead(ctx, d, m)
 }
 
-func addGroupUsers(m interface{}, targetGroupID string, userIDs []string) error {
-	client := m.(*apiclient.LookerSDK)
+func addGroupUsers(ctx context.Context, m interface{}, targetGroupID string, userIDs []string) error {
+	client := m.(*lookerClient)
 
-	for _, userID := range userIDs {
+	return forEachParallel(ctx, client.bulkConcurrency, userIDs, func(userID string) error {
 		body := apiclient.GroupIdForGroupUserInclusion{
 			UserId: &userID,
 		}
@@ -172,28 +186,26 @@ func addGroupUsers(m interface{}, targetGroupID string, userIDs []string) error
 		if err != nil {
 			return wrapSDKError(err, "AddGroupUser", "group_membership", "%s:%s", targetGroupID, userID)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
-func checkUsersExist(m interface{}, userIDs []string) error {
-	client := m.(*apiclient.LookerSDK)
+func checkUsersExist(ctx context.Context, m interface{}, userIDs []string) error {
+	client := m.(*lookerClient)
 
-	for _, userID := range userIDs {
+	return forEachParallel(ctx, client.bulkConcurrency, userIDs, func(userID string) error {
 		_, err := client.User(userID, "", nil)
 		if err != nil {
 			return wrapSDKError(fmt.Errorf("error fetching user with id %s: %w", userID, err), "User", "group_membership", "%s", userID)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
-func addGroupGroups(m interface{}, targetGroupID string, groupIDs []string) error {
-	client := m.(*apiclient.LookerSDK)
+func addGroupGroups(ctx context.Context, m interface{}, targetGroupID string, groupIDs []string) error {
+	client := m.(*lookerClient)
 
-	for _, groupID := range groupIDs {
+	return forEachParallel(ctx, client.bulkConcurrency, groupIDs, func(groupID string) error {
 		body := apiclient.GroupIdForGroupInclusion{
 			GroupId: &groupID,
 		}
@@ -202,9 +214,8 @@ func addGroupGroups(m interface{}, targetGroupID string, groupIDs []string) erro
 		if err != nil {
 			return wrapSDKError(err, "AddGroupGroup", "group_membership", "%s:%s", targetGroupID, groupID)
 		}
-	}
-
-	return nil
+		return nil
+	})
 }
 
 func contains[T comparable](slice []T, value T) bool {
@@ -216,44 +227,56 @@ func contains[T comparable](slice []T, value T) bool {
 	return false
 }
 
-func removeAllUsersFromGroup(m interface{}, groupID string, protectedUserIDs []string) error {
-	client := m.(*apiclient.LookerSDK)
-	req := apiclient.RequestAllGroupUsers{
-		GroupId: groupID,
-	}
+func removeAllUsersFromGroup(ctx context.Context, m interface{}, groupID string, protectedUserIDs []string) error {
+	client := m.(*lookerClient)
 
-	users, err := client.AllGroupUsers(req, nil) // todo: imeplement paging
+	users, err := allGroupUsers(client.LookerSDK, groupID)
 	if err != nil {
 		return wrapSDKError(err, "AllGroupUsers", "group_membership", "%s", groupID)
 	}
 
-	for _, user := range users {
-		if protectedUserIDs == nil || !contains(protectedUserIDs, *user.Id) {
-			err = client.DeleteGroupUser(groupID, *user.Id, nil)
-			if err != nil {
-				return wrapSDKError(err, "DeleteGroupUser", "group_membership", "%s:%s", groupID, *user.Id)
-			}
-		}
-	}
+	return removeGroupUsers(ctx, m, groupID, flattenUserIDs(users), protectedUserIDs)
+}
 
-	return nil
+// removeGroupUsers removes the users from the group, except for the protected ones.
+// Users that are no longer members are skipped, so that an interrupted removal can be resumed.
+func removeGroupUsers(ctx context.Context, m interface{}, groupID string, userIDs []string, protectedUserIDs []string) error {
+	client := m.(*lookerClient)
+
+	userIDs = slices.DeleteFunc(slices.Clone(userIDs), func(userID string) bool {
+		return contains(protectedUserIDs, userID)
+	})
+	return forEachParallel(ctx, client.bulkConcurrency, userIDs, func(userID string) error {
+		err := client.DeleteGroupUser(groupID, userID, nil)
+		if err != nil && !isNotFound(err) {
+			return wrapSDKError(err, "DeleteGroupUser", "group_membership", "%s:%s", groupID, userID)
+		}
+		return nil
+	})
 }
 
-func removeAllGroupsFromGroup(m interface{}, groupID string) error {
-	client := m.(*apiclient.LookerSDK)
-	groups, err := client.AllGroupGroups(groupID, "", nil) // todo: imeplement paging
+func removeAllGroupsFromGroup(ctx context.Context, m interface{}, groupID string) error {
+	client := m.(*lookerClient)
+	// unlike group users, group groups are not paginated
+	groups, err := client.AllGroupGroups(groupID, "", nil)
 	if err != nil {
 		return wrapSDKError(err, "AllGroupGroups", "group_membership", "%s", groupID)
 	}
 
-	for _, group := range groups {
-		err = client.DeleteGroupFromGroup(groupID, *group.Id, nil)
-		if err != nil {
-			return wrapSDKError(err, "DeleteGroupFromGroup", "group_membership", "%s:%s", groupID, *group.Id)
-		}
-	}
+	return removeGroupGroups(ctx, m, groupID, flattenGroupIDs(groups))
+}
 
-	return nil
+// removeGroupGroups removes the groups from the group. Groups that are no longer included are skipped.
+func removeGroupGroups(ctx context.Context, m interface{}, groupID string, groupIDs []string) error {
+	client := m.(*lookerClient)
+
+	return forEachParallel(ctx, client.bulkConcurrency, groupIDs, func(includedGroupID string) error {
+		err := client.DeleteGroupFromGroup(groupID, includedGroupID, nil)
+		if err != nil && !isNotFound(err) {
+			return wrapSDKError(err, "DeleteGroupFromGroup", "group_membership", "%s:%s", groupID, includedGroupID)
+		}
+		return nil
+	})
 }
 
 func flattenUserIDs(users []apiclient.User) []string {
@@ -1,14 +1,20 @@
 package looker
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAcc_GroupMembership(t *testing.T) {
@@ -79,6 +85,116 @@ func TestAcc_GroupMembership(t *testing.T) {
 	})
 }
 
+func TestResourceGroupMembershipUpdate(t *testing.T) {
+	recorder := &membershipChangeRecorder{base: http.DefaultTransport}
+	_, client := newTestClientWithTransport(t, recorder)
+
+	newGroup := func(name string) string {
+		group, err := client.CreateGroup(apiclient.WriteGroup{Name: &name}, "", nil)
+		require.NoError(t, err)
+		return *group.Id
+	}
+	newUser := func(name string) string {
+		user, err := client.CreateUser(apiclient.WriteUser{FirstName: &name}, "", nil)
+		require.NoError(t, err)
+		return *user.Id
+	}
+	target := newGroup("target")
+	group1, group2 := newGroup("group1"), newGroup("group2")
+	user1, user2, user3, user4 := newUser("user1"), newUser("user2"), newUser("user3"), newUser("user4")
+
+	r := resourceGroupMembership()
+	apply := func(state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, error) {
+		t.Helper()
+		state, diags := r.Apply(context.Background(), state, testDiff(t, r, client, state, config), client)
+		if diags.HasError() {
+			return state, fmt.Errorf("%v", diags)
+		}
+		return state, nil
+	}
+
+	state, err := apply(nil, map[string]interface{}{
+		"target_group_id": target,
+		"user_ids":        []interface{}{user1, user2, user3},
+		"group_ids":       []interface{}{group1},
+	})
+	require.NoError(t, err)
+
+	update := map[string]interface{}{
+		"target_group_id":           target,
+		"user_ids":                  []interface{}{user1, user4},
+		"group_ids":                 []interface{}{group2},
+		"delete_protected_user_ids": []interface{}{user3},
+	}
+
+	// a failed update keeps the prior state
+	recorder.failing = "DELETE"
+	failed, err := apply(state, update)
+	require.Error(t, err)
+	assert.Equal(t, state.Attributes["user_ids.#"], failed.Attributes["user_ids.#"])
+
+	// and the next apply resumes with what is left of the delta after a refresh
+	recorder.failing = ""
+	recorder.reset()
+	state, err = apply(testRefresh(t, r, client, failed), update)
+	require.NoError(t, err)
+
+	// members that stay are never removed, members added by the failed apply are not added again,
+	// and the protected user is kept in the group
+	assert.ElementsMatch(t, []string{
+		"DELETE /api/4.0/groups/" + target + "/users/" + user2,
+		"DELETE /api/4.0/groups/" + target + "/groups/" + group1,
+	}, recorder.changes())
+
+	users, err := allGroupUsers(client.LookerSDK, target)
+	require.NoError(t, err)
+	userIDs := flattenUserIDs(users)
+	sort.Strings(userIDs)
+	assert.Equal(t, []string{user1, user3, user4}, userIDs)
+	assert.Equal(t, "3", state.Attributes["user_ids.#"])
+}
+
+// membershipChangeRecorder records the requests changing group memberships,
+// and fails the ones with the failing method.
+type membershipChangeRecorder struct {
+	base    http.RoundTripper
+	failing string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (r *membershipChangeRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || !strings.Contains(req.URL.Path, "/groups/") {
+		return r.base.RoundTrip(req)
+	}
+	if req.Method == r.failing {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Status:     "500 Internal Server Error",
+			Header:     http.Header{},
+			Body:       http.NoBody,
+			Request:    req,
+		}, nil
+	}
+	r.mu.Lock()
+	r.requests = append(r.requests, req.Method+" "+req.URL.Path)
+	r.mu.Unlock()
+	return r.base.RoundTrip(req)
+}
+
+func (r *membershipChangeRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = nil
+}
+
+func (r *membershipChangeRecorder) changes() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.requests...)
+}
+
 func testAccCheckGroupMembershipExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -89,7 +205,7 @@ func testAccCheckGroupMembershipExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("no group membership setting ID is set")
 		}
 
-		client := testAccProvider.Meta().(*apiclient.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 		targetGroupID := rs.Primary.ID
 
 		users, _ := client.AllGroupUsers(apiclient.RequestAllGroupUsers{GroupId: targetGroupID}, nil)
@@ -105,7 +221,7 @@ func testAccCheckGroupMembershipExists(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckGroupMembershipDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_group_membership" {
@@ -116,7 +232,7 @@ func testAccCheckGroupMembershipDestroy(s *terraform.State) error {
 
 		users, err := client.AllGroupUsers(apiclient.RequestAllGroupUsers{GroupId: targetGroupID}, nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
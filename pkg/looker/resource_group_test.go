@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_Group(t *testing.T) {
@@ -42,7 +41,7 @@ func TestAcc_Group(t *testing.T) {
 }
 
 func testAccCheckGroupDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_group" {
@@ -53,7 +52,7 @@ func testAccCheckGroupDestroy(s *terraform.State) error {
 
 		group, err := client.Group(groupID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -0,0 +1,120 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceGroupUser() *schema.Resource {
+	return &schema.Resource{
+		Description: "Adds a single user to a group without managing the other members of the group. " +
+			"Do not use it together with looker_group_membership for the same group, which removes the members it does not manage.",
+		CreateContext: resourceGroupUserCreate,
+		ReadContext:   resourceGroupUserRead,
+		DeleteContext: resourceGroupUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceGroupUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	groupID := d.Get("group_id").(string)
+	userID := d.Get("user_id").(string)
+
+	body := apiclient.GroupIdForGroupUserInclusion{
+		UserId: &userID,
+	}
+
+	_, err := client.AddGroupUser(groupID, body, nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "AddGroupUser", "group_user", "%s:%s", groupID, userID))
+	}
+
+	d.SetId(buildTwoPartID(&groupID, &userID))
+
+	return resourceGroupUserRead(ctx, d, m)
+}
+
+func resourceGroupUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	groupID, userID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	member, err := isGroupMember(client.LookerSDK, groupID, userID)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "SearchUsers", "group_user", "%s:%s", groupID, userID))
+	}
+	if !member {
+		// the user was removed from the group, or the group was deleted, outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("group_id", groupID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("user_id", userID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGroupUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	groupID, userID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.DeleteGroupUser(groupID, userID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteGroupUser", "group_user", "%s:%s", groupID, userID))
+	}
+
+	return nil
+}
+
+// isGroupMember reports whether the user is a direct member of the group. It searches for the user
+// instead of listing the members, which are many in the large groups group_user resources are used for.
+func isGroupMember(client *apiclient.LookerSDK, groupID, userID string) (bool, error) {
+	users, err := client.SearchUsers(apiclient.RequestSearchUsers{
+		Fields:  ptrTo("id"),
+		Id:      &userID,
+		GroupId: &groupID,
+	}, nil)
+	if err != nil {
+		return false, err
+	}
+	for _, user := range users {
+		if valueOrZero(user.Id) == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
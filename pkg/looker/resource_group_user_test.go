@@ -0,0 +1,162 @@
+package looker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_GroupUser(t *testing.T) {
+	group := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	user1 := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	user2 := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: groupUserConfig(group, user1, user2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckGroupUserExists("looker_group_user.test1"),
+					testAccCheckGroupUserExists("looker_group_user.test2"),
+					resource.TestCheckResourceAttrPair("looker_group_user.test1", "group_id", "looker_group.test", "id"),
+					resource.TestCheckResourceAttrPair("looker_group_user.test1", "user_id", "looker_user.test1", "id"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_group_user.test1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckGroupUserDestroy,
+	})
+}
+
+func testAccCheckGroupUserExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("group user not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*lookerClient)
+		groupID, userID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		member, err := isGroupMember(client.LookerSDK, groupID, userID)
+		if err != nil {
+			return err
+		}
+		if !member {
+			return fmt.Errorf("user %s is not a member of group %s", userID, groupID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckGroupUserDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_group_user" {
+			continue
+		}
+
+		groupID, userID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		member, err := isGroupMember(client.LookerSDK, groupID, userID)
+		if err != nil {
+			return err
+		}
+		if member {
+			return fmt.Errorf("group_user '%s' still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func TestIsGroupMember(t *testing.T) {
+	_, client := newTestClient(t)
+
+	group, err := client.CreateGroup(apiclient.WriteGroup{Name: ptrTo("analysts")}, "", nil)
+	require.NoError(t, err)
+	var userIDs []string
+	for _, name := range []string{"member", "other"} {
+		user, err := client.CreateUser(apiclient.WriteUser{FirstName: ptrTo(name)}, "", nil)
+		require.NoError(t, err)
+		userIDs = append(userIDs, *user.Id)
+	}
+	_, err = client.AddGroupUser(*group.Id, apiclient.GroupIdForGroupUserInclusion{UserId: &userIDs[0]}, nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		groupID string
+		userID  string
+		want    bool
+	}{
+		"member": {
+			groupID: *group.Id,
+			userID:  userIDs[0],
+			want:    true,
+		},
+		"not a member": {
+			groupID: *group.Id,
+			userID:  userIDs[1],
+		},
+		"deleted group": {
+			groupID: "404",
+			userID:  userIDs[0],
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			member, err := isGroupMember(client.LookerSDK, tt.groupID, tt.userID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, member)
+		})
+	}
+}
+
+func groupUserConfig(group, user1, user2 string) string {
+	return fmt.Sprintf(`
+	resource "looker_group" "test" {
+		name = "%s"
+	}
+	resource "looker_user" "test1" {
+        first_name = "%s"
+        last_name  = "%s"
+        email      = "%s@example.com"
+	}
+	resource "looker_user" "test2" {
+        first_name = "%s"
+        last_name  = "%s"
+        email      = "%s@example.com"
+	}
+	resource "looker_group_user" "test1" {
+		group_id = looker_group.test.id
+		user_id  = looker_user.test1.id
+	}
+	resource "looker_group_user" "test2" {
+		group_id = looker_group.test.id
+		user_id  = looker_user.test2.id
+	}
+	`, group, user1, user1, user1, user2, user2, user2)
+}
@@ -0,0 +1,310 @@
+package looker
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceLook() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a saved Look and its query.\n\n" +
+			"Looker queries are immutable, so a new query is created and attached to the Look whenever the `query` block changes. " +
+			"A Look moved to the trash outside of Terraform is created again.",
+		CreateContext: resourceLookCreate,
+		ReadContext:   resourceLookRead,
+		UpdateContext: resourceLookUpdate,
+		DeleteContext: resourceLookDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"title": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Title of the Look.",
+			},
+			"folder_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "ID of the folder the Look is stored in.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the Look.",
+			},
+			"is_run_on_load": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the query runs when the Look is opened.",
+			},
+			"public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the Look can be viewed without logging in to Looker.",
+			},
+			"query": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "Query of the Look.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"model": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Name of the LookML model of the query.",
+						},
+						"view": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Name of the explore of the query.",
+						},
+						"fields": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MinItems:    1,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Fields selected by the query, like `orders.count`.",
+						},
+						"filters": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Filters of the query, from field name to Looker filter expression.",
+						},
+						"sorts": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Sorts of the query, like `orders.count desc`.",
+						},
+						"pivots": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Fields the query results are pivoted on.",
+						},
+						"limit": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+$`), "must be a number of rows"),
+							Description:  "Row limit of the query.",
+						},
+						"dynamic_fields": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							Description:      "JSON array of the custom fields and table calculations of the query.",
+						},
+					},
+				},
+			},
+			"query_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the current query of the Look.",
+			},
+			"content_metadata_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the content metadata of the Look, to manage its access with looker_content_metadata_access.",
+			},
+			"sudo_as_user_id": sudoAsUserIDSchema(),
+		},
+	}
+}
+
+func resourceLookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	queryID, err := createLookQuery(client, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	writeLook := expandWriteLook(d)
+	writeLook.QueryId = &queryID
+	look, err := client.CreateLook(writeLook, "", nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "CreateLook", "look", "%s", *writeLook.Title))
+	}
+	d.SetId(*look.Id)
+
+	return resourceLookRead(ctx, d, m)
+}
+
+func resourceLookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	lookID := d.Id()
+
+	look, err := client.Look(lookID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "Look", "look", "%s", lookID))
+	}
+	if valueOrZero(look.Deleted) {
+		// the look was moved to the trash outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	query := look.Query
+	if query == nil && look.QueryId != nil {
+		result, err := client.Query(*look.QueryId, "", nil)
+		if err != nil {
+			return diag.FromErr(wrapSDKError(err, "Query", "look", "look=%s, query=%s", lookID, *look.QueryId))
+		}
+		query = &result
+	}
+
+	if err = d.Set("title", look.Title); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("folder_id", look.FolderId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", look.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("is_run_on_load", look.IsRunOnLoad); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("public", look.Public); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("query_id", look.QueryId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("content_metadata_id", look.ContentMetadataId); err != nil {
+		return diag.FromErr(err)
+	}
+	if query != nil {
+		if err = d.Set("query", flattenLookQuery(*query)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceLookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	lookID := d.Id()
+
+	writeLook := expandWriteLook(d)
+	if d.HasChange("query") {
+		queryID, err := createLookQuery(client, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		writeLook.QueryId = &queryID
+	}
+
+	_, err := client.UpdateLook(lookID, writeLook, "", nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "UpdateLook", "look", "%s", lookID))
+	}
+
+	return resourceLookRead(ctx, d, m)
+}
+
+func resourceLookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	lookID := d.Id()
+
+	_, err := client.DeleteLook(lookID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteLook", "look", "%s", lookID))
+	}
+
+	return nil
+}
+
+func expandWriteLook(d *schema.ResourceData) apiclient.WriteLookWithQuery {
+	return apiclient.WriteLookWithQuery{
+		Title:       ptrTo(d.Get("title").(string)),
+		FolderId:    ptrTo(d.Get("folder_id").(string)),
+		Description: ptrTo(d.Get("description").(string)),
+		IsRunOnLoad: ptrTo(d.Get("is_run_on_load").(bool)),
+		Public:      ptrTo(d.Get("public").(bool)),
+	}
+}
+
+// createLookQuery creates the query of the query block and returns its ID.
+func createLookQuery(client *apiclient.LookerSDK, d *schema.ResourceData) (string, error) {
+	writeQuery := expandLookQuery(d.Get("query").([]interface{})[0].(map[string]interface{}))
+	query, err := client.CreateQuery(writeQuery, "", nil)
+	if err != nil {
+		return "", wrapSDKError(err, "CreateQuery", "look", "model=%s, view=%s", writeQuery.Model, writeQuery.View)
+	}
+	return *query.Id, nil
+}
+
+func expandLookQuery(query map[string]interface{}) apiclient.WriteQuery {
+	writeQuery := apiclient.WriteQuery{
+		Model:  query["model"].(string),
+		View:   query["view"].(string),
+		Fields: ptrTo(expandStringList(query["fields"])),
+	}
+	if filters := query["filters"].(map[string]interface{}); len(filters) > 0 {
+		writeQuery.Filters = &filters
+	}
+	if sorts := expandStringList(query["sorts"]); len(sorts) > 0 {
+		writeQuery.Sorts = &sorts
+	}
+	if pivots := expandStringList(query["pivots"]); len(pivots) > 0 {
+		writeQuery.Pivots = &pivots
+	}
+	if v := query["limit"].(string); v != "" {
+		writeQuery.Limit = &v
+	}
+	if v := query["dynamic_fields"].(string); v != "" {
+		writeQuery.DynamicFields = &v
+	}
+	return writeQuery
+}
+
+func flattenLookQuery(query apiclient.Query) []interface{} {
+	filters := map[string]interface{}{}
+	for field, value := range valueOrZero(query.Filters) {
+		if s, ok := value.(string); ok {
+			filters[field] = s
+		}
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"model":          query.Model,
+			"view":           query.View,
+			"fields":         flattenStringList(valueOrZero(query.Fields)),
+			"filters":        filters,
+			"sorts":          flattenStringList(valueOrZero(query.Sorts)),
+			"pivots":         flattenStringList(valueOrZero(query.Pivots)),
+			"limit":          valueOrZero(query.Limit),
+			"dynamic_fields": valueOrZero(query.DynamicFields),
+		},
+	}
+}
@@ -0,0 +1,180 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_Look(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	var queryID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: lookConfig(name, "500"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_look.test", "title", name),
+					resource.TestCheckResourceAttrPair("looker_look.test", "folder_id", "looker_folder.test", "id"),
+					resource.TestCheckResourceAttr("looker_look.test", "query.0.fields.#", "2"),
+					resource.TestCheckResourceAttr("looker_look.test", "query.0.filters.orders.status", "complete"),
+					resource.TestCheckResourceAttr("looker_look.test", "query.0.limit", "500"),
+					resource.TestCheckResourceAttrSet("looker_look.test", "content_metadata_id"),
+					storeAttr("looker_look.test", "query_id", &queryID),
+				),
+			},
+			// Test: Update
+			{
+				Config: lookConfig(name+"_UPDATED", "100"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_look.test", "title", name+"_UPDATED"),
+					resource.TestCheckResourceAttr("looker_look.test", "query.0.limit", "100"),
+					func(s *terraform.State) error {
+						updated := s.RootModule().Resources["looker_look.test"].Primary.Attributes["query_id"]
+						if updated == queryID {
+							return fmt.Errorf("query %s was not replaced", queryID)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_look.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckLookDestroy,
+	})
+}
+
+func TestResourceLook(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceLook()
+
+	query := map[string]interface{}{
+		"model":          "thelook",
+		"view":           "orders",
+		"fields":         []interface{}{"orders.created_date", "orders.count"},
+		"filters":        map[string]interface{}{"orders.status": "complete"},
+		"sorts":          []interface{}{"orders.created_date desc"},
+		"limit":          "500",
+		"dynamic_fields": `[{"table_calculation": "share", "expression": "${orders.count} / sum(${orders.count})"}]`,
+	}
+	config := map[string]interface{}{
+		"title":          "Orders",
+		"folder_id":      fakelooker.SharedFolderID,
+		"is_run_on_load": true,
+		"query":          []interface{}{query},
+	}
+	state := testApply(t, r, client, nil, config)
+	lookID, queryID := state.ID, state.Attributes["query_id"]
+
+	look, err := client.Look(lookID, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Orders", *look.Title)
+	assert.True(t, *look.IsRunOnLoad)
+	assert.Equal(t, queryID, *look.QueryId)
+	assert.Equal(t, []string{"orders.created_date", "orders.count"}, *look.Query.Fields)
+	assert.Equal(t, map[string]interface{}{"orders.status": "complete"}, *look.Query.Filters)
+	assert.Nil(t, testDiff(t, r, client, testRefresh(t, r, client, state), config))
+
+	// attribute changes keep the query
+	config["description"] = "Completed orders"
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, lookID, state.ID)
+	assert.Equal(t, queryID, state.Attributes["query_id"])
+
+	// query changes create a new query, as queries are immutable
+	query["limit"] = "100"
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, lookID, state.ID)
+	assert.NotEqual(t, queryID, state.Attributes["query_id"])
+	assert.Equal(t, "100", state.Attributes["query.0.limit"])
+	assert.Nil(t, testDiff(t, r, client, testRefresh(t, r, client, state), config))
+
+	// a query replaced outside of Terraform is detected
+	other, err := client.CreateQuery(apiclient.WriteQuery{Model: "thelook", View: "orders", Fields: &[]string{"orders.count"}}, "", nil)
+	require.NoError(t, err)
+	_, err = client.UpdateLook(lookID, apiclient.WriteLookWithQuery{QueryId: other.Id}, "", nil)
+	require.NoError(t, err)
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, *other.Id, state.Attributes["query_id"])
+	assert.Equal(t, "1", state.Attributes["query.0.fields.#"])
+	d := testDiff(t, r, client, state, config)
+	require.NotNil(t, d)
+	assert.Contains(t, d.Attributes, "query.0.fields.#")
+	state = testApply(t, r, client, state, config)
+
+	// a look moved to the trash is removed from the state
+	_, err = client.UpdateLook(lookID, apiclient.WriteLookWithQuery{Deleted: ptrTo(true)}, "", nil)
+	require.NoError(t, err)
+	assert.Nil(t, testRefresh(t, r, client, state))
+
+	// the look is deleted for good
+	_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	_, err = client.Look(lookID, "", nil)
+	assert.True(t, isNotFound(err))
+}
+
+func testAccCheckLookDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_look" {
+			continue
+		}
+
+		_, err := client.Look(rs.Primary.ID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		return fmt.Errorf("look '%s' still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func lookConfig(title, limit string) string {
+	return fmt.Sprintf(`
+	resource "looker_folder" "test" {
+		name      = "%s"
+		parent_id = "1"
+	}
+	resource "looker_look" "test" {
+		title       = "%s"
+		description = "Managed by Terraform"
+		folder_id   = looker_folder.test.id
+
+		query {
+			model  = "thelook"
+			view   = "orders"
+			fields = ["orders.created_date", "orders.count"]
+			filters = {
+				"orders.status" = "complete"
+			}
+			sorts = ["orders.created_date desc"]
+			limit = "%s"
+		}
+	}
+	`, title, title, limit)
+}
@@ -2,7 +2,6 @@ package looker
 
 import (
 	"context"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -37,7 +36,7 @@ func resourceLookMLModel() *schema.Resource {
 }
 
 func resourceLookMLModelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	body := expandWriteLookmlModel(d)
 
@@ -52,11 +51,11 @@ func resourceLookMLModelCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceLookMLModelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	model, err := client.LookmlModel(d.Id(), "", nil)
 	if err != nil {
-		if strings.Contains(err.Error(), "Not found") {
+		if isNotFound(err) {
 			d.SetId("")
 			return nil
 		}
@@ -67,7 +66,7 @@ func resourceLookMLModelRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceLookMLModelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	body := expandWriteLookmlModel(d)
 
@@ -80,12 +79,15 @@ func resourceLookMLModelUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceLookMLModelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	modelName := d.Get("name").(string)
 
 	_, err := client.DeleteLookmlModel(d.Id(), nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteLookmlModel", "lookml_model", "name=%s, id=%s", modelName, d.Id()))
 	}
 
@@ -14,6 +14,7 @@ func resourceModelSet() *schema.Resource {
 		ReadContext:   resourceModelSetRead,
 		UpdateContext: resourceModelSetUpdate,
 		DeleteContext: resourceModelSetDelete,
+		CustomizeDiff: validateModelSetModels,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -24,16 +25,58 @@ func resourceModelSet() *schema.Resource {
 				Required: true,
 			},
 			"models": {
-				Type:     schema.TypeSet,
-				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the LookML models of the set. They must exist at plan time, unless the provider sets `allow_missing_models`",
 			},
 		},
 	}
 }
 
+// validateModelSetModels checks that the models exist on the instance, unless the provider allows missing models.
+func validateModelSetModels(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client := m.(*lookerClient)
+	if client.allowMissingModels || !d.HasChange("models") || !d.NewValueKnown("models") {
+		return nil
+	}
+
+	// like the permissions, the models are listed as the admin, whichever user the resource acts as
+	valid, err := allLookmlModelNames(client.admin)
+	if err != nil {
+		return wrapSDKError(err, "AllLookmlModels", "model_set", "")
+	}
+	return validateNamesExist("models", "model", expandStringListFromSet(d.Get("models")), valid)
+}
+
+// allLookmlModelNames returns the names of every LookML model of the instance, including the built-in ones,
+// like System Activity, and the self-service ones, which model sets can name too.
+func allLookmlModelNames(client *apiclient.LookerSDK) ([]string, error) {
+	fields := "name"
+	models, err := paginate(defaultPageSize, func(limit, offset int64) ([]apiclient.LookmlModel, error) {
+		return client.AllLookmlModels(apiclient.RequestAllLookmlModels{
+			Fields:             &fields,
+			Limit:              &limit,
+			Offset:             &offset,
+			IncludeInternal:    ptrTo(true),
+			IncludeSelfService: ptrTo(true),
+		}, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		if model.Name != nil {
+			names = append(names, *model.Name)
+		}
+	}
+	return names, nil
+}
+
 func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	modelSetName := d.Get("name").(string)
 
@@ -59,12 +102,16 @@ func resourceModelSetCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	modelSetID := d.Id()
 
 	modelSet, err := client.ModelSet(modelSetID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "ModelSet", "model_set", "%s", modelSetID))
 	}
 
@@ -79,7 +126,7 @@ func resourceModelSetRead(ctx context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	modelSetID := d.Id()
 	modelSetName := d.Get("name").(string)
@@ -100,13 +147,16 @@ func resourceModelSetUpdate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceModelSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	modelSetID := d.Id()
 	modelSetName := d.Get("name").(string)
 
 	_, err := client.DeleteModelSet(modelSetID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteModelSet", "model_set", "name=%s, id=%s", modelSetName, modelSetID))
 	}
 
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_ModelSet(t *testing.T) {
@@ -44,7 +43,7 @@ func TestAcc_ModelSet(t *testing.T) {
 }
 
 func testAccCheckModelSetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_model_set" {
@@ -55,7 +54,7 @@ func testAccCheckModelSetDestroy(s *terraform.State) error {
 
 		modelSet, err := client.ModelSet(modelSetID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
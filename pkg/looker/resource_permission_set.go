@@ -14,6 +14,7 @@ func resourcePermissionSet() *schema.Resource {
 		ReadContext:   resourcePermissionSetRead,
 		UpdateContext: resourcePermissionSetUpdate,
 		DeleteContext: resourcePermissionSetDelete,
+		CustomizeDiff: validatePermissionSetPermissions,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -24,16 +25,30 @@ func resourcePermissionSet() *schema.Resource {
 				Required: true,
 			},
 			"permissions": {
-				Type:     schema.TypeSet,
-				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the permissions of the set. They are checked against the permissions of the instance at plan time",
 			},
 		},
 	}
 }
 
+// validatePermissionSetPermissions checks that the permissions exist on the instance.
+func validatePermissionSetPermissions(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("permissions") || !d.NewValueKnown("permissions") {
+		return nil
+	}
+
+	valid, err := m.(*lookerClient).permissionNames()
+	if err != nil {
+		return wrapSDKError(err, "AllPermissions", "permission_set", "")
+	}
+	return validateNamesExist("permissions", "permission", expandStringListFromSet(d.Get("permissions")), valid)
+}
+
 func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	permissionSetName := d.Get("name").(string)
 
@@ -59,12 +74,16 @@ func resourcePermissionSetCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	permissionSetID := d.Id()
 
 	permissionSet, err := client.PermissionSet(permissionSetID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "PermissionSet", "permission_set", "%s", permissionSetID))
 	}
 
@@ -78,7 +97,7 @@ func resourcePermissionSetRead(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	permissionSetID := d.Id()
 
@@ -100,13 +119,16 @@ func resourcePermissionSetUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourcePermissionSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	permissionSetID := d.Id()
 	permissionSetName := d.Get("name").(string)
 
 	_, err := client.DeletePermissionSet(permissionSetID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeletePermissionSet", "permission_set", "name=%s, id=%s", permissionSetName, permissionSetID))
 	}
 
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_PermissionSet(t *testing.T) {
@@ -23,14 +22,14 @@ func TestAcc_PermissionSet(t *testing.T) {
 				Config: permissionSetConfig(name1),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("looker_permission_set.test", "name", name1),
-					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "2"),
 				),
 			},
 			{
 				Config: permissionSetConfig(name2),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("looker_permission_set.test", "name", name2),
-					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("looker_permission_set.test", "permissions.#", "2"),
 				),
 			},
 			{
@@ -44,7 +43,7 @@ func TestAcc_PermissionSet(t *testing.T) {
 }
 
 func testAccCheckPermissionSetDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_permission_set" {
@@ -55,7 +54,7 @@ func testAccCheckPermissionSetDestroy(s *terraform.State) error {
 
 		permissionSet, err := client.PermissionSet(permissionSetID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -72,7 +71,7 @@ func permissionSetConfig(name string) string {
 	return fmt.Sprintf(`
 	resource "looker_permission_set" "test" {
 		name = "%s"
-		permissions = ["test"]
+		permissions = ["access_data", "see_looks"]
 	}
 	`, name)
 }
@@ -0,0 +1,181 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceProject() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a LookML project and its Git configuration.\n\n" +
+			"Looker only lets projects be changed in the dev workspace, so the provider manages them through a separate API session " +
+			"in the dev workspace, while the other resources keep using production. " +
+			"With `access_token` or `access_token_file` authentication, opening that session requires the `sudo` permission. " +
+			"The Looker API can't delete projects: destroying the resource only removes it from the Terraform state.",
+		CreateContext: resourceProjectCreate,
+		ReadContext:   resourceProjectRead,
+		UpdateContext: resourceProjectUpdate,
+		DeleteContext: resourceProjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9_-]+$`), "must only contain alphanumerics, dashes and underscores"),
+				Description:  "Name of the project, which is also its ID.",
+			},
+			"git_remote_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "URL of the remote Git repository, like `git@github.com:example/analytics.git`.",
+			},
+			"git_service_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the Git service, like `github`. Looker detects it from the remote URL when it is not set.",
+			},
+			"git_production_branch_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Branch of the remote repository that is deployed to production.",
+			},
+			"pull_request_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(apiclient.PullRequestMode_Off),
+				ValidateFunc: validation.StringInSlice([]string{"off", "links", "recommended", "required"}, false),
+				Description:  "Pull request policy of the project: `off`, `links`, `recommended` or `required`.",
+			},
+			"validation_required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the LookML must validate before changes are committed.",
+			},
+			"allow_warnings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether LookML with warnings, but without errors, can be committed when `validation_required` is set.",
+			},
+		},
+	}
+}
+
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	name := d.Get("name").(string)
+
+	// Looker only accepts the name on creation, the Git configuration is set by an update
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		project, err := dev.CreateProject(apiclient.WriteProject{Name: &name}, nil)
+		if err != nil {
+			return wrapSDKError(err, "CreateProject", "project", "%s", name)
+		}
+		d.SetId(*project.Id)
+
+		_, err = dev.UpdateProject(d.Id(), expandWriteProject(d), "", nil)
+		return wrapSDKError(err, "UpdateProject", "project", "%s", d.Id())
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectRead(ctx, d, m)
+}
+
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	projectID := d.Id()
+
+	var project apiclient.Project
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		var err error
+		project, err = dev.Project(projectID, "", nil)
+		return err
+	})
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "Project", "project", "%s", projectID))
+	}
+
+	if err = d.Set("name", project.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("git_remote_url", project.GitRemoteUrl); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("git_service_name", project.GitServiceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("git_production_branch_name", project.GitProductionBranchName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("pull_request_mode", valueOrZero(project.PullRequestMode)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("validation_required", project.ValidationRequired); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("allow_warnings", project.AllowWarnings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	projectID := d.Id()
+
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		_, err := dev.UpdateProject(projectID, expandWriteProject(d), "", nil)
+		return wrapSDKError(err, "UpdateProject", "project", "%s", projectID)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectRead(ctx, d, m)
+}
+
+func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Project not deleted",
+		Detail:   fmt.Sprintf("The Looker API can't delete projects. The project %s was only removed from the Terraform state, delete it in Looker.", d.Id()),
+	}}
+}
+
+func expandWriteProject(d *schema.ResourceData) apiclient.WriteProject {
+	writeProject := apiclient.WriteProject{
+		GitRemoteUrl:       ptrTo(d.Get("git_remote_url").(string)),
+		PullRequestMode:    ptrTo(apiclient.PullRequestMode(d.Get("pull_request_mode").(string))),
+		ValidationRequired: ptrTo(d.Get("validation_required").(bool)),
+		AllowWarnings:      ptrTo(d.Get("allow_warnings").(bool)),
+	}
+	if v, ok := d.GetOk("git_service_name"); ok {
+		writeProject.GitServiceName = ptrTo(v.(string))
+	}
+	if v, ok := d.GetOk("git_production_branch_name"); ok {
+		writeProject.GitProductionBranchName = ptrTo(v.(string))
+	}
+	return writeProject
+}
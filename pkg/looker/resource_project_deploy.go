@@ -0,0 +1,174 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceProjectDeploy() *schema.Resource {
+	return &schema.Resource{
+		Description: "Deploys a Git ref of a LookML project to production.\n\n" +
+			"The ref is deployed when the resource is created and every time it changes. " +
+			"Applies wait for the deploy to complete. " +
+			"With `validate_before_deploy`, the dev branch of the provider user is reset to the ref and validated first: " +
+			"LookML errors fail the apply and nothing is deployed. " +
+			"Destroying the resource doesn't undeploy anything, it only removes the resource from the Terraform state.",
+		CreateContext: resourceProjectDeployCreate,
+		ReadContext:   resourceProjectDeployRead,
+		UpdateContext: resourceProjectDeployUpdate,
+		DeleteContext: resourceProjectDeployDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "ID of the project.",
+			},
+			"ref": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Git ref to deploy, like a commit SHA or a branch name.",
+			},
+			"validate_before_deploy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the LookML of the ref is validated before it is deployed.",
+			},
+		},
+	}
+}
+
+func resourceProjectDeployCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := deployProject(d, m, d.Timeout(schema.TimeoutCreate))
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(d.Get("project_id").(string))
+
+	return append(diags, resourceProjectDeployRead(ctx, d, m)...)
+}
+
+func resourceProjectDeployRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	projectID := d.Id()
+
+	// the project is only visible in production once it has been deployed
+	_, err := client.Project(projectID, "id", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "Project", "project_deploy", "%s", projectID))
+	}
+
+	if err = d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectDeployUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if !d.HasChange("ref") {
+		return resourceProjectDeployRead(ctx, d, m)
+	}
+
+	// keep the prior ref when the deploy fails, so that the next apply retries it
+	d.Partial(true)
+	diags := deployProject(d, m, d.Timeout(schema.TimeoutUpdate))
+	if diags.HasError() {
+		return diags
+	}
+	d.Partial(false)
+
+	return append(diags, resourceProjectDeployRead(ctx, d, m)...)
+}
+
+func resourceProjectDeployDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// production can't be undeployed
+	return nil
+}
+
+// deployProject deploys the ref to production, after validating it when validate_before_deploy is set.
+// Deploys and validations of large projects can take minutes, so the API calls are given the timeout of the operation.
+func deployProject(d *schema.ResourceData, m interface{}, timeout time.Duration) diag.Diagnostics {
+	client := m.(*lookerClient)
+	projectID := d.Get("project_id").(string)
+	ref := d.Get("ref").(string)
+	options := &rtl.ApiSettings{Timeout: int32(timeout.Seconds())}
+
+	var diags diag.Diagnostics
+	if d.Get("validate_before_deploy").(bool) {
+		var result apiclient.ProjectValidation
+		err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+			// Looker validates the checked out branch, so the dev branch is reset to the ref
+			_, err := dev.UpdateGitBranch(projectID, apiclient.WriteGitBranch{Ref: &ref}, nil)
+			if err != nil {
+				return wrapSDKError(err, "UpdateGitBranch", "project_deploy", "%s", projectID)
+			}
+			result, err = dev.ValidateProject(projectID, "", options)
+			return wrapSDKError(err, "ValidateProject", "project_deploy", "%s", projectID)
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		diags = lookMLDiagnostics(projectID, ref, result)
+		if diags.HasError() {
+			return diags
+		}
+	}
+
+	_, err := client.DeployRefToProduction(apiclient.RequestDeployRefToProduction{ProjectId: projectID, Ref: &ref}, options)
+	if err != nil {
+		return append(diags, diag.FromErr(wrapSDKError(err, "DeployRefToProduction", "project_deploy", "%s@%s", projectID, ref))...)
+	}
+	return diags
+}
+
+// lookMLDiagnostics turns the fatal errors and errors of the validation into error diagnostics and its warnings into warnings.
+func lookMLDiagnostics(projectID, ref string, result apiclient.ProjectValidation) diag.Diagnostics {
+	if result.Errors == nil {
+		return nil
+	}
+
+	var diags diag.Diagnostics
+	for _, e := range *result.Errors {
+		var severity diag.Severity
+		switch valueOrZero(e.Severity) {
+		case "fatal", "error":
+			severity = diag.Error
+		case "warning":
+			severity = diag.Warning
+		default:
+			continue
+		}
+		location := valueOrZero(e.FilePath)
+		if e.LineNumber != nil {
+			location = fmt.Sprintf("%s:%d", location, *e.LineNumber)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary:  fmt.Sprintf("LookML %s in %s@%s", valueOrZero(e.Severity), projectID, ref),
+			Detail:   fmt.Sprintf("%s: %s", location, valueOrZero(e.Message)),
+		})
+	}
+	return diags
+}
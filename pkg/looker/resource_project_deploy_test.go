@@ -0,0 +1,145 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAcc_ProjectDeploy deploys the refs set in LOOKER_TEST_DEPLOY_REFS, a comma separated pair of commits,
+// of the project set in LOOKER_TEST_DEPLOY_PROJECT. The project must have a Git repository Looker can pull.
+func TestAcc_ProjectDeploy(t *testing.T) {
+	projectID := os.Getenv("LOOKER_TEST_DEPLOY_PROJECT")
+	refs := strings.Split(os.Getenv("LOOKER_TEST_DEPLOY_REFS"), ",")
+	if projectID == "" || len(refs) != 2 {
+		t.Skip("LOOKER_TEST_DEPLOY_PROJECT and LOOKER_TEST_DEPLOY_REFS must be set to deploy a project")
+	}
+	name := acctest.RandomWithPrefix("deploy")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: projectDeployConfig(name, projectID, refs[0]),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_deploy."+name, "project_id", projectID),
+					resource.TestCheckResourceAttr("looker_project_deploy."+name, "ref", refs[0]),
+				),
+			},
+			// Test: Update
+			{
+				Config: projectDeployConfig(name, projectID, refs[1]),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project_deploy."+name, "ref", refs[1]),
+				),
+			},
+		},
+		// no CheckDestroy: production can't be undeployed
+	})
+}
+
+func TestResourceProjectDeploy(t *testing.T) {
+	srv, client := newTestClient(t)
+
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		for _, name := range []string{"analytics", "without_git"} {
+			if _, err := dev.CreateProject(apiclient.WriteProject{Name: ptrTo(name)}, nil); err != nil {
+				return err
+			}
+		}
+		_, err := dev.UpdateProject("analytics", apiclient.WriteProject{GitRemoteUrl: ptrTo("git@github.com:example/analytics.git")}, "", nil)
+		return err
+	})
+	require.NoError(t, err)
+	srv.AddLookMLError("analytics", "broken", "error", "views/orders.view.lkml", "Unknown or inaccessible field \"orders.total\"")
+	srv.AddLookMLError("analytics", "deprecated", "warning", "models/analytics.model.lkml", "\"sql_trigger_value\" is deprecated")
+
+	r := resourceProjectDeploy()
+	productionRef := func() string {
+		t.Helper()
+		branch, err := client.GitBranch("analytics", nil)
+		require.NoError(t, err)
+		return valueOrZero(branch.Ref)
+	}
+
+	// deploy errors are reported
+	config := map[string]interface{}{"project_id": "without_git", "ref": "0a1b2c3"}
+	_, diags := r.Apply(context.Background(), nil, testDiff(t, r, client, nil, config), client)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "Git must be configured")
+
+	// refs with LookML errors are not deployed
+	config = map[string]interface{}{"project_id": "analytics", "ref": "broken", "validate_before_deploy": true}
+	state, diags := r.Apply(context.Background(), nil, testDiff(t, r, client, nil, config), client)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "LookML error in analytics@broken", diags[0].Summary)
+	assert.Equal(t, "views/orders.view.lkml:1: Unknown or inaccessible field \"orders.total\"", diags[0].Detail)
+	assert.True(t, state == nil || state.ID == "", "the resource is not created")
+	_, err = client.Project("analytics", "", nil)
+	assert.True(t, isNotFound(err), "the project was never deployed")
+
+	// LookML warnings are reported but don't prevent the deploy
+	config["ref"] = "deprecated"
+	state, diags = r.Apply(context.Background(), nil, testDiff(t, r, client, nil, config), client)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Equal(t, "analytics", state.ID)
+	assert.Equal(t, "deprecated", productionRef())
+
+	// the dev branch of the provider user was reset to the validated ref
+	err = client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		branch, err := dev.GitBranch("analytics", nil)
+		if err == nil {
+			assert.Equal(t, "deprecated", valueOrZero(branch.Ref))
+		}
+		return err
+	})
+	require.NoError(t, err)
+
+	// changing the ref deploys it, but a failed validation keeps the prior ref
+	config["ref"] = "broken"
+	failed, diags := r.Apply(context.Background(), state, testDiff(t, r, client, state, config), client)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "deprecated", failed.Attributes["ref"])
+	assert.Equal(t, "deprecated", productionRef())
+
+	config["ref"] = "4d5e6f7"
+	config["validate_before_deploy"] = false
+	state, diags = r.Apply(context.Background(), state, testDiff(t, r, client, state, config), client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "4d5e6f7", state.Attributes["ref"])
+	assert.Equal(t, "4d5e6f7", productionRef())
+
+	// the deployed ref isn't read back, it is only deployed again when the configuration changes
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "analytics", state.ID)
+	assert.Nil(t, testDiff(t, r, client, state, config))
+
+	// destroying the resource keeps production as is
+	_, diags = r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "4d5e6f7", productionRef())
+}
+
+func projectDeployConfig(name, projectID, ref string) string {
+	return fmt.Sprintf(`
+	resource "looker_project_deploy" "%s" {
+		project_id             = "%s"
+		ref                    = "%s"
+		validate_before_deploy = true
+	}
+	`, name, projectID, ref)
+}
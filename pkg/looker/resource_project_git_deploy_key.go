@@ -0,0 +1,97 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceProjectGitDeployKey() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the SSH deploy key Looker uses to access the remote Git repository of a project.\n\n" +
+			"The existing key of the project is reused, and a key pair is generated when the project has none. " +
+			"Add `public_key` to the deploy keys of the repository. " +
+			"The Looker API can't delete deploy keys: destroying the resource only removes it from the Terraform state.",
+		CreateContext: resourceProjectGitDeployKeyCreate,
+		ReadContext:   resourceProjectGitDeployKeyRead,
+		DeleteContext: resourceProjectGitDeployKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "ID of the project.",
+			},
+			"public_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Public SSH key of the project.",
+			},
+		},
+	}
+}
+
+func resourceProjectGitDeployKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	projectID := d.Get("project_id").(string)
+
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		_, err := dev.GitDeployKey(projectID, nil)
+		if err == nil {
+			return nil
+		}
+		if !isNotFound(err) {
+			return wrapSDKError(err, "GitDeployKey", "project_git_deploy_key", "%s", projectID)
+		}
+		_, err = dev.CreateGitDeployKey(projectID, nil)
+		return wrapSDKError(err, "CreateGitDeployKey", "project_git_deploy_key", "%s", projectID)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(projectID)
+
+	return resourceProjectGitDeployKeyRead(ctx, d, m)
+}
+
+func resourceProjectGitDeployKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	projectID := d.Id()
+
+	var publicKey string
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		var err error
+		publicKey, err = dev.GitDeployKey(projectID, nil)
+		return err
+	})
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "GitDeployKey", "project_git_deploy_key", "%s", projectID))
+	}
+
+	if err = d.Set("project_id", projectID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("public_key", publicKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceProjectGitDeployKeyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// the Looker API can't delete deploy keys
+	return nil
+}
@@ -0,0 +1,65 @@
+package looker
+
+import (
+	"testing"
+
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceProjectGitDeployKey(t *testing.T) {
+	_, client := newTestClient(t)
+
+	var existingKey string
+	err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		for _, name := range []string{"new_key", "existing_key"} {
+			if _, err := dev.CreateProject(apiclient.WriteProject{Name: ptrTo(name)}, nil); err != nil {
+				return err
+			}
+		}
+		var err error
+		existingKey, err = dev.CreateGitDeployKey("existing_key", nil)
+		return err
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		projectID string
+		wantKey   string
+	}{
+		"a key is generated": {
+			projectID: "new_key",
+		},
+		"the existing key is kept": {
+			projectID: "existing_key",
+			wantKey:   existingKey,
+		},
+	}
+
+	r := resourceProjectGitDeployKey()
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			state := testApply(t, r, client, nil, map[string]interface{}{
+				"project_id": tt.projectID,
+			})
+
+			assert.Equal(t, tt.projectID, state.ID)
+			assert.Contains(t, state.Attributes["public_key"], "ssh-rsa ")
+			if tt.wantKey != "" {
+				assert.Equal(t, tt.wantKey, state.Attributes["public_key"])
+			}
+
+			// a key regenerated outside of Terraform is detected
+			var regenerated string
+			err := client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+				var err error
+				regenerated, err = dev.CreateGitDeployKey(tt.projectID, nil)
+				return err
+			})
+			require.NoError(t, err)
+			state = testRefresh(t, r, client, state)
+			assert.Equal(t, regenerated, state.Attributes["public_key"])
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_Project(t *testing.T) {
+	name := strings.ToLower(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: projectConfig(name, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test", "name", name),
+					resource.TestCheckResourceAttr("looker_project.test", "git_production_branch_name", "main"),
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "off"),
+					resource.TestCheckResourceAttrPair("looker_project_git_deploy_key.test", "project_id", "looker_project.test", "id"),
+					resource.TestCheckResourceAttrSet("looker_project_git_deploy_key.test", "public_key"),
+				),
+			},
+			// Test: Update
+			{
+				Config: projectConfig(name, "required"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_project.test", "pull_request_mode", "required"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "looker_project_git_deploy_key.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		// no CheckDestroy: the Looker API can't delete projects
+	})
+}
+
+func TestResourceProject(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceProject()
+	assertProductionWorkspace := func() {
+		t.Helper()
+		session, err := client.Session(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "production", *session.WorkspaceId)
+	}
+
+	config := map[string]interface{}{
+		"name":                       "analytics",
+		"git_remote_url":             "git@github.com:example/analytics.git",
+		"git_production_branch_name": "main",
+		"pull_request_mode":          "recommended",
+		"validation_required":        true,
+	}
+	state := testApply(t, r, client, nil, config)
+	assert.Equal(t, "analytics", state.ID)
+	assert.Equal(t, "github", state.Attributes["git_service_name"], "Looker detects the Git service")
+	assert.Equal(t, "true", state.Attributes["allow_warnings"])
+	assertProductionWorkspace()
+
+	// the project was never deployed, so it only exists in the dev workspace
+	_, err := client.Project("analytics", "", nil)
+	assert.True(t, isNotFound(err))
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "analytics", state.ID)
+	assert.Nil(t, testDiff(t, r, client, state, config))
+	assertProductionWorkspace()
+
+	config["pull_request_mode"] = "required"
+	config["allow_warnings"] = false
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, "required", state.Attributes["pull_request_mode"])
+	assert.Equal(t, "false", state.Attributes["allow_warnings"])
+	assertProductionWorkspace()
+
+	// changes made outside of Terraform are detected
+	err = client.inDevWorkspace(func(dev *apiclient.LookerSDK) error {
+		_, err := dev.UpdateProject("analytics", apiclient.WriteProject{GitProductionBranchName: ptrTo("release")}, "", nil)
+		return err
+	})
+	require.NoError(t, err)
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "release", state.Attributes["git_production_branch_name"])
+	assert.NotNil(t, testDiff(t, r, client, state, config))
+
+	// the project can't be deleted, which is reported
+	_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "analytics")
+}
+
+func projectConfig(name, pullRequestMode string) string {
+	return fmt.Sprintf(`
+	resource "looker_project" "test" {
+		name                       = "%s"
+		git_remote_url             = "git@github.com:example/%s.git"
+		git_production_branch_name = "main"
+		pull_request_mode          = "%s"
+	}
+	resource "looker_project_git_deploy_key" "test" {
+		project_id = looker_project.test.id
+	}
+	`, name, name, pullRequestMode)
+}
@@ -0,0 +1,128 @@
+package looker
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceRoleGroup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Assigns a role to a single group, keeping the groups the role is assigned to elsewhere. " +
+			"Do not use it together with looker_role_groups for the same role, which removes the groups it does not manage.",
+		CreateContext: resourceRoleGroupCreate,
+		ReadContext:   resourceRoleGroupRead,
+		DeleteContext: resourceRoleGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceRoleGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	roleID := d.Get("role_id").(string)
+	groupID := d.Get("group_id").(string)
+
+	unlock := client.lock("role_groups:" + roleID)
+	defer unlock()
+
+	groups, err := client.RoleGroups(roleID, "", nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "RoleGroups", "role_group", "%s:%s", roleID, groupID))
+	}
+
+	groupIDs := flattenGroupIDs(groups)
+	if !contains(groupIDs, groupID) {
+		_, err = client.SetRoleGroups(roleID, append(groupIDs, groupID), nil)
+		if err != nil {
+			return diag.FromErr(wrapSDKError(err, "SetRoleGroups", "role_group", "%s:%s", roleID, groupID))
+		}
+	}
+
+	d.SetId(buildTwoPartID(&roleID, &groupID))
+
+	return resourceRoleGroupRead(ctx, d, m)
+}
+
+func resourceRoleGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	roleID, groupID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	groups, err := client.RoleGroups(roleID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "RoleGroups", "role_group", "%s:%s", roleID, groupID))
+	}
+	if !contains(flattenGroupIDs(groups), groupID) {
+		// the role was removed from the group outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("role_id", roleID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("group_id", groupID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceRoleGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	roleID, groupID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock := client.lock("role_groups:" + roleID)
+	defer unlock()
+
+	groups, err := client.RoleGroups(roleID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "RoleGroups", "role_group", "%s:%s", roleID, groupID))
+	}
+
+	groupIDs := flattenGroupIDs(groups)
+	if !contains(groupIDs, groupID) {
+		return nil
+	}
+
+	_, err = client.SetRoleGroups(roleID, slices.DeleteFunc(groupIDs, func(id string) bool { return id == groupID }), nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "SetRoleGroups", "role_group", "%s:%s", roleID, groupID))
+	}
+
+	return nil
+}
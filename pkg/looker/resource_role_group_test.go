@@ -0,0 +1,124 @@
+package looker
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAcc_RoleGroup(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: roleGroupConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckRoleGroupAssigned("looker_role_group.test1"),
+					testAccCheckRoleGroupAssigned("looker_role_group.test2"),
+					resource.TestCheckResourceAttrPair("looker_role_group.test1", "role_id", "looker_role.test", "id"),
+					resource.TestCheckResourceAttrPair("looker_role_group.test1", "group_id", "looker_group.test1", "id"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_role_group.test1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckRoleGroupUnassigned,
+	})
+}
+
+func testAccCheckRoleGroupAssigned(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("role group not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*lookerClient)
+		roleID, groupID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.RoleGroups(roleID, "", nil)
+		if err != nil {
+			return err
+		}
+		if !contains(flattenGroupIDs(groups), groupID) {
+			return fmt.Errorf("role %s is not assigned to group %s", roleID, groupID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckRoleGroupUnassigned(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_role_group" {
+			continue
+		}
+
+		roleID, groupID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		groups, err := client.RoleGroups(roleID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		if contains(flattenGroupIDs(groups), groupID) {
+			return fmt.Errorf("role_group '%s' still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func roleGroupConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "looker_group" "test1" {
+		name = "%s_1"
+	}
+	resource "looker_group" "test2" {
+		name = "%s_2"
+	}
+	resource "looker_model_set" "test" {
+		name = "%s"
+		models = ["test"]
+	}
+	resource "looker_permission_set" "test" {
+		name = "%s"
+		permissions = ["access_data"]
+	}
+	resource "looker_role" "test" {
+		name = "%s"
+		permission_set_id = looker_permission_set.test.id
+		model_set_id = looker_model_set.test.id
+	}
+	resource "looker_role_group" "test1" {
+		role_id  = looker_role.test.id
+		group_id = looker_group.test1.id
+	}
+	resource "looker_role_group" "test2" {
+		role_id  = looker_role.test.id
+		group_id = looker_group.test2.id
+	}
+	`, name, name, name, name, name)
+}
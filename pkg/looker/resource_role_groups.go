@@ -5,7 +5,6 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func resourceRoleGroups() *schema.Resource {
@@ -33,7 +32,7 @@ func resourceRoleGroups() *schema.Resource {
 }
 
 func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	roleID := d.Get("role_id").(string)
 
@@ -54,12 +53,16 @@ func resourceRoleGroupsCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	roleID := d.Id()
 
 	groups, err := client.RoleGroups(roleID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "RoleGroups", "role_groups", "%s", roleID))
 	}
 
@@ -81,7 +84,7 @@ func resourceRoleGroupsRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	roleID := d.Id()
 
@@ -100,13 +103,16 @@ func resourceRoleGroupsUpdate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceRoleGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	roleID := d.Id()
 
 	groupIDs := []string{}
 	_, err := client.SetRoleGroups(roleID, groupIDs, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "SetRoleGroups", "role_groups", "%s", roleID))
 	}
 
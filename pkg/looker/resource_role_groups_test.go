@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_RoleGroups(t *testing.T) {
@@ -35,7 +34,7 @@ func TestAcc_RoleGroups(t *testing.T) {
 }
 
 func testAccCheckRoleGroupsDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_role_groups" {
@@ -46,7 +45,7 @@ func testAccCheckRoleGroupsDestroy(s *terraform.State) error {
 
 		groups, err := client.RoleGroups(roleGroupsID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -70,7 +69,7 @@ func roleGroupsConfig(name string) string {
 	}
 	resource "looker_permission_set" "test" {
 		name = "%s"
-		permissions = ["test"]
+		permissions = ["access_data", "see_looks"]
 	}
 	resource "looker_role" "test" {
 		name = "%s"
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_Role(t *testing.T) {
@@ -35,7 +34,7 @@ func TestAcc_Role(t *testing.T) {
 }
 
 func testAccCheckRoleDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_role" {
@@ -46,7 +45,7 @@ func testAccCheckRoleDestroy(s *terraform.State) error {
 
 		role, err := client.Role(roleID, nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -67,7 +66,7 @@ func roleConfig(name string) string {
 	}
 	resource "looker_permission_set" "role_test" {
 		name = "%s"
-		permissions = ["test"]
+		permissions = ["access_data", "see_looks"]
 	}
 	resource "looker_role" "role_test" {
 		name = "%s"
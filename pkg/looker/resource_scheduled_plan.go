@@ -0,0 +1,348 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+var (
+	scheduledPlanTargets  = []string{"look_id", "dashboard_id", "lookml_dashboard_id"}
+	scheduledPlanTriggers = []string{"crontab", "datagroup"}
+)
+
+func resourceScheduledPlan() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a scheduled delivery of a Look, a dashboard or a LookML dashboard.\n\n" +
+			"The plan is owned by the user Terraform acts as, which is the user of `sudo_as_user_id` when it is set. " +
+			"Admins can also give the plan to another user with `user_id`.",
+		CreateContext: resourceScheduledPlanCreate,
+		ReadContext:   resourceScheduledPlanRead,
+		UpdateContext: resourceScheduledPlanUpdate,
+		DeleteContext: resourceScheduledPlanDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Name of the scheduled plan.",
+			},
+			"user_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the user who owns the scheduled plan. Defaults to the user Terraform acts as. Only admins can set another user.",
+			},
+			"look_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanTargets,
+				Description:  "ID of the Look to deliver.",
+			},
+			"dashboard_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanTargets,
+				Description:  "ID of the dashboard to deliver.",
+			},
+			"lookml_dashboard_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: scheduledPlanTargets,
+				Description:  "ID of the LookML dashboard to deliver, like `model::dashboard_name`.",
+			},
+			"crontab": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: scheduledPlanTriggers,
+				Description:  "Vixie-style crontab of the times the plan runs at, like `0 9 * * 1-5`.",
+			},
+			"datagroup": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: scheduledPlanTriggers,
+				Description:  "Name of the datagroup whose triggers run the plan.",
+			},
+			"timezone": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Timezone the crontab is interpreted in. Defaults to the timezone of the Looker instance.",
+			},
+			"filters_string": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Query string of the filters to run the Look or dashboard with, like `?Country=Japan`.",
+			},
+			"run_as_recipient": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the plan runs with the permissions and user attributes of each email recipient.",
+			},
+			"include_links": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the deliveries link back to Looker.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the plan runs.",
+			},
+			"destination": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Destinations the plan delivers to.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"email", "webhook", "s3", "sftp", "action_hub"}, false),
+							Description:  "Type of the destination: `email`, `webhook`, `s3`, `sftp` or `action_hub`.",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Address of the destination: an email address, or the URL of the webhook, S3 bucket, SFTP server or action. Required unless the type is `action_hub`.",
+						},
+						"format": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Format of the data, like `csv`, `json_detail` or `wysiwyg_pdf`. The supported formats depend on the type of destination.",
+						},
+						"apply_formatting": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the values are formatted, with currency symbols and digit separators.",
+						},
+						"apply_vis": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the visualization options are applied to the results.",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Message included in the emails.",
+						},
+						"parameters": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: structure.SuppressJsonDiff,
+							Description:      "JSON object of the parameters of the destination, like the `region` of an S3 bucket or the form values of an action.",
+						},
+						"secret_parameters": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validation.StringIsJSON,
+							Description: "JSON object of the secret parameters of the destination, like the `secret_access_key` of an S3 bucket or the `password` of an SFTP server. " +
+								"Looker does not return them, so changes made outside of Terraform are not detected.",
+						},
+					},
+				},
+			},
+			"sudo_as_user_id": sudoAsUserIDSchema(),
+		},
+	}
+}
+
+func resourceScheduledPlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	writeScheduledPlan := expandWriteScheduledPlan(d)
+	writeScheduledPlan.LookId = optionalString(d, "look_id")
+	writeScheduledPlan.DashboardId = optionalString(d, "dashboard_id")
+	writeScheduledPlan.LookmlDashboardId = optionalString(d, "lookml_dashboard_id")
+
+	scheduledPlan, err := client.CreateScheduledPlan(writeScheduledPlan, nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "CreateScheduledPlan", "scheduled_plan", "%s", *writeScheduledPlan.Name))
+	}
+	d.SetId(*scheduledPlan.Id)
+
+	return resourceScheduledPlanRead(ctx, d, m)
+}
+
+func resourceScheduledPlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	scheduledPlanID := d.Id()
+
+	scheduledPlan, err := client.ScheduledPlan(scheduledPlanID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "ScheduledPlan", "scheduled_plan", "%s", scheduledPlanID))
+	}
+
+	if err = d.Set("name", scheduledPlan.Name); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("user_id", scheduledPlan.UserId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("look_id", scheduledPlan.LookId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("dashboard_id", scheduledPlan.DashboardId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("lookml_dashboard_id", scheduledPlan.LookmlDashboardId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("crontab", scheduledPlan.Crontab); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("datagroup", scheduledPlan.Datagroup); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("timezone", scheduledPlan.Timezone); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("filters_string", scheduledPlan.FiltersString); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("run_as_recipient", scheduledPlan.RunAsRecipient); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("include_links", scheduledPlan.IncludeLinks); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("enabled", scheduledPlan.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("destination", flattenScheduledPlanDestinations(d, valueOrZero(scheduledPlan.ScheduledPlanDestination))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceScheduledPlanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	scheduledPlanID := d.Id()
+
+	// Looker replaces all the destinations of the plan with the ones sent
+	_, err := client.UpdateScheduledPlan(scheduledPlanID, expandWriteScheduledPlan(d), nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "UpdateScheduledPlan", "scheduled_plan", "%s", scheduledPlanID))
+	}
+
+	return resourceScheduledPlanRead(ctx, d, m)
+}
+
+func resourceScheduledPlanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := clientFor(d, m)
+
+	scheduledPlanID := d.Id()
+
+	_, err := client.DeleteScheduledPlan(scheduledPlanID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteScheduledPlan", "scheduled_plan", "%s", scheduledPlanID))
+	}
+
+	return nil
+}
+
+// expandWriteScheduledPlan builds the plan from the attributes, except the target, which can't be changed.
+// The trigger and filters are always sent, so that unsetting them clears them in Looker.
+func expandWriteScheduledPlan(d *schema.ResourceData) apiclient.WriteScheduledPlan {
+	return apiclient.WriteScheduledPlan{
+		Name:                     ptrTo(d.Get("name").(string)),
+		UserId:                   optionalString(d, "user_id"),
+		Crontab:                  ptrTo(d.Get("crontab").(string)),
+		Datagroup:                ptrTo(d.Get("datagroup").(string)),
+		Timezone:                 optionalString(d, "timezone"),
+		FiltersString:            ptrTo(d.Get("filters_string").(string)),
+		RunAsRecipient:           ptrTo(d.Get("run_as_recipient").(bool)),
+		IncludeLinks:             ptrTo(d.Get("include_links").(bool)),
+		Enabled:                  ptrTo(d.Get("enabled").(bool)),
+		ScheduledPlanDestination: ptrTo(expandScheduledPlanDestinations(d.Get("destination").([]interface{}))),
+	}
+}
+
+func expandScheduledPlanDestinations(destinations []interface{}) []apiclient.ScheduledPlanDestination {
+	expanded := make([]apiclient.ScheduledPlanDestination, 0, len(destinations))
+	for _, v := range destinations {
+		destination := v.(map[string]interface{})
+		scheduledPlanDestination := apiclient.ScheduledPlanDestination{
+			Type:            ptrTo(destination["type"].(string)),
+			Format:          ptrTo(destination["format"].(string)),
+			ApplyFormatting: ptrTo(destination["apply_formatting"].(bool)),
+			ApplyVis:        ptrTo(destination["apply_vis"].(bool)),
+		}
+		if v := destination["address"].(string); v != "" {
+			scheduledPlanDestination.Address = &v
+		}
+		if v := destination["message"].(string); v != "" {
+			scheduledPlanDestination.Message = &v
+		}
+		if v := destination["parameters"].(string); v != "" {
+			scheduledPlanDestination.Parameters = &v
+		}
+		if v := destination["secret_parameters"].(string); v != "" {
+			scheduledPlanDestination.SecretParameters = &v
+		}
+		expanded = append(expanded, scheduledPlanDestination)
+	}
+	return expanded
+}
+
+// flattenScheduledPlanDestinations flattens the destinations, keeping the write-only secret parameters
+// of the destination at the same position in the state.
+func flattenScheduledPlanDestinations(d *schema.ResourceData, destinations []apiclient.ScheduledPlanDestination) []interface{} {
+	current := d.Get("destination").([]interface{})
+	flattened := make([]interface{}, 0, len(destinations))
+	for i, destination := range destinations {
+		secretParameters := ""
+		if i < len(current) {
+			secretParameters = current[i].(map[string]interface{})["secret_parameters"].(string)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"type":              valueOrZero(destination.Type),
+			"address":           valueOrZero(destination.Address),
+			"format":            valueOrZero(destination.Format),
+			"apply_formatting":  valueOrZero(destination.ApplyFormatting),
+			"apply_vis":         valueOrZero(destination.ApplyVis),
+			"message":           valueOrZero(destination.Message),
+			"parameters":        valueOrZero(destination.Parameters),
+			"secret_parameters": secretParameters,
+		})
+	}
+	return flattened
+}
+
+// optionalString returns the value of the attribute, or nil when it is not set.
+func optionalString(d *schema.ResourceData, key string) *string {
+	if v, ok := d.GetOk(key); ok {
+		return ptrTo(v.(string))
+	}
+	return nil
+}
@@ -0,0 +1,250 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_ScheduledPlan(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: scheduledPlanConfig(name, "0 9 * * 1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_scheduled_plan.test", "name", name),
+					resource.TestCheckResourceAttrPair("looker_scheduled_plan.test", "dashboard_id", "looker_dashboard.test", "id"),
+					resource.TestCheckResourceAttrPair("looker_scheduled_plan.test", "user_id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttr("looker_scheduled_plan.test", "crontab", "0 9 * * 1"),
+					resource.TestCheckResourceAttr("looker_scheduled_plan.test", "destination.#", "2"),
+					resource.TestCheckResourceAttr("looker_scheduled_plan.test", "destination.1.type", "webhook"),
+				),
+			},
+			// Test: Update
+			{
+				Config: scheduledPlanConfig(name, "0 9 * * 1-5"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_scheduled_plan.test", "crontab", "0 9 * * 1-5"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:            "looker_scheduled_plan.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"sudo_as_user_id", "destination.1.secret_parameters"},
+			},
+		},
+		CheckDestroy: testAccCheckScheduledPlanDestroy,
+	})
+}
+
+func TestResourceScheduledPlan(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceScheduledPlan()
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	user, err := client.CreateUser(apiclient.WriteUser{FirstName: &name}, "", nil)
+	require.NoError(t, err)
+	dashboard, err := client.CreateDashboard(apiclient.WriteDashboard{Title: &name, FolderId: ptrTo(fakelooker.SharedFolderID)}, nil)
+	require.NoError(t, err)
+
+	config := map[string]interface{}{
+		"name":            "Weekly sales",
+		"dashboard_id":    *dashboard.Id,
+		"crontab":         "0 9 * * 1",
+		"filters_string":  "?Country=Japan",
+		"include_links":   true,
+		"sudo_as_user_id": *user.Id,
+		"destination": []interface{}{
+			map[string]interface{}{"type": "email", "address": "sales@example.com", "format": "wysiwyg_pdf"},
+			map[string]interface{}{
+				"type":              "s3",
+				"address":           "s3://reports/sales",
+				"format":            "csv",
+				"parameters":        `{"region": "us-east-1", "access_key_id": "AKIA"}`,
+				"secret_parameters": `{"secret_access_key": "secret"}`,
+			},
+		},
+	}
+	state := testApply(t, r, client, nil, config)
+	planID := state.ID
+
+	// the plan is owned by the user Terraform acted as
+	assert.Equal(t, *user.Id, state.Attributes["user_id"])
+	plan, err := client.ScheduledPlan(planID, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, *user.Id, *plan.UserId)
+	require.Len(t, *plan.ScheduledPlanDestination, 2)
+	assert.Nil(t, (*plan.ScheduledPlanDestination)[1].SecretParameters)
+
+	// the secret parameters, which can't be read back, are kept on refresh
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, `{"secret_access_key": "secret"}`, state.Attributes["destination.1.secret_parameters"])
+	assert.Nil(t, testDiff(t, r, client, state, config))
+
+	// destinations are replaced as a whole, and the trigger can be switched
+	delete(config, "crontab")
+	config["datagroup"] = "orders_etl"
+	config["destination"] = []interface{}{
+		map[string]interface{}{"type": "webhook", "address": "https://example.com/hook", "format": "json_detail"},
+	}
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, planID, state.ID)
+	assert.Equal(t, "", state.Attributes["crontab"])
+	assert.Equal(t, "orders_etl", state.Attributes["datagroup"])
+	assert.Equal(t, "1", state.Attributes["destination.#"])
+	assert.Nil(t, testDiff(t, r, client, testRefresh(t, r, client, state), config))
+
+	// changes made outside of Terraform are detected
+	_, err = client.UpdateScheduledPlan(planID, apiclient.WriteScheduledPlan{Enabled: ptrTo(false)}, nil)
+	require.NoError(t, err)
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "false", state.Attributes["enabled"])
+	d := testDiff(t, r, client, state, config)
+	require.NotNil(t, d)
+	assert.Contains(t, d.Attributes, "enabled")
+
+	// a deleted plan is removed from the state
+	_, err = client.DeleteScheduledPlan(planID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, testRefresh(t, r, client, state))
+}
+
+func TestResourceScheduledPlanOwner(t *testing.T) {
+	_, client := newTestClient(t)
+
+	name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+	owner, err := client.CreateUser(apiclient.WriteUser{FirstName: &name}, "", nil)
+	require.NoError(t, err)
+	other, err := client.CreateUser(apiclient.WriteUser{FirstName: &name}, "", nil)
+	require.NoError(t, err)
+	dashboard, err := client.CreateDashboard(apiclient.WriteDashboard{Title: &name, FolderId: ptrTo(fakelooker.SharedFolderID)}, nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		userID    string
+		sudoAs    string
+		wantOwner string
+		wantErr   string
+	}{
+		"the provider user by default": {
+			wantOwner: fakelooker.AdminUserID,
+		},
+		"the sudo user": {
+			sudoAs:    *owner.Id,
+			wantOwner: *owner.Id,
+		},
+		"another user, set by an admin": {
+			userID:    *owner.Id,
+			wantOwner: *owner.Id,
+		},
+		"another user, set by a user who is not an admin": {
+			userID:  *owner.Id,
+			sudoAs:  *other.Id,
+			wantErr: "Only admins can schedule on behalf of other users",
+		},
+	}
+
+	r := resourceScheduledPlan()
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			config := map[string]interface{}{
+				"name":         key,
+				"dashboard_id": *dashboard.Id,
+				"crontab":      "0 9 * * *",
+				"destination": []interface{}{
+					map[string]interface{}{"type": "email", "address": "sales@example.com", "format": "wysiwyg_pdf"},
+				},
+			}
+			if tt.userID != "" {
+				config["user_id"] = tt.userID
+			}
+			if tt.sudoAs != "" {
+				config["sudo_as_user_id"] = tt.sudoAs
+			}
+			state, diags := r.Apply(context.Background(), nil, testDiff(t, r, client, nil, config), client)
+			if tt.wantErr != "" {
+				require.True(t, diags.HasError())
+				assert.Contains(t, diags[0].Summary, tt.wantErr)
+				return
+			}
+			require.False(t, diags.HasError(), "%v", diags)
+			assert.Equal(t, tt.wantOwner, state.Attributes["user_id"])
+		})
+	}
+}
+
+func testAccCheckScheduledPlanDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_scheduled_plan" {
+			continue
+		}
+
+		_, err := client.ScheduledPlan(rs.Primary.ID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		return fmt.Errorf("scheduled_plan '%s' still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func scheduledPlanConfig(name, crontab string) string {
+	return fmt.Sprintf(`
+	resource "looker_user" "test" {
+		first_name = "%s"
+		last_name  = "%s"
+		email      = "%s@example.com"
+	}
+	resource "looker_folder" "test" {
+		name      = "%s"
+		parent_id = "1"
+	}
+	resource "looker_dashboard" "test" {
+		title     = "%s"
+		folder_id = looker_folder.test.id
+	}
+	resource "looker_scheduled_plan" "test" {
+		name            = "%s"
+		dashboard_id    = looker_dashboard.test.id
+		crontab         = "%s"
+		timezone        = "UTC"
+		include_links   = true
+		sudo_as_user_id = looker_user.test.id
+
+		destination {
+			type    = "email"
+			address = "%s@example.com"
+			format  = "wysiwyg_pdf"
+		}
+		destination {
+			type              = "webhook"
+			address           = "https://example.com/hook"
+			format            = "json_detail"
+			secret_parameters = jsonencode({ token = "secret" })
+		}
+	}
+	`, name, name, name, name, name, name, crontab, name)
+}
@@ -40,7 +40,7 @@ func resourceServiceAccount() *schema.Resource {
 }
 
 func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	name := d.Get("service_account_name").(string)
 	isDisabled := d.Get("is_disabled").(bool)
@@ -61,12 +61,16 @@ func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 
 	user, err := client.User(userID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "User", "service_account", "%s", userID))
 	}
 
@@ -81,7 +85,7 @@ func resourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 	name := d.Get("service_account_name").(string)
@@ -101,13 +105,16 @@ func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceServiceAccountDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 	name := d.Get("service_account_name").(string)
 
 	_, err := client.DeleteServiceAccount(userID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteServiceAccount", "service_account", "name=%s, id=%s", name, userID))
 	}
 
@@ -2,13 +2,11 @@ package looker
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_ServiceAccount(t *testing.T) {
@@ -63,7 +61,7 @@ func TestAcc_ServiceAccountUpdate(t *testing.T) {
 }
 
 func testAccCheckServiceAccountDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_service_account" {
@@ -74,7 +72,7 @@ func testAccCheckServiceAccountDestroy(s *terraform.State) error {
 
 		_, err := client.User(userID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -2,15 +2,27 @@ package looker
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
+const (
+	userDeletionModeDelete  = "delete"
+	userDeletionModeDisable = "disable"
+	userDeletionModeAbandon = "abandon"
+)
+
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceUserCreate,
@@ -18,7 +30,7 @@ func resourceUser() *schema.Resource {
 		UpdateContext: resourceUserUpdate,
 		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceUserImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"email": {
@@ -42,31 +54,114 @@ func resourceUser() *schema.Resource {
 				Optional: true,
 				Default:  false,
 			},
+			"deletion_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      userDeletionModeDelete,
+				ValidateFunc: validation.StringInSlice([]string{userDeletionModeDelete, userDeletionModeDisable, userDeletionModeAbandon}, false),
+				Description: "What happens to the user when the resource is destroyed: `delete` deletes the user, " +
+					"`disable` disables the user and keeps their content, `abandon` only removes the user from the Terraform state.",
+			},
+			"content_transfer_folder_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "ID of a folder that the folders, looks and dashboards of the personal folder of the user are moved to " +
+					"before the user is deleted or disabled.",
+			},
+			"locale": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Preferred locale of the user, such as `en` or `en-US`, overriding the default locale of the instance.",
+			},
+			"home_folder_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the home folder of the user.",
+			},
+			"models_dir_validated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the dev workspace of the user has been checked for the production projects it needs.",
+			},
+			"ui_state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: structure.SuppressJsonDiff,
+				Description:      "JSON encoded state of the Looker UI for the user. Its content is undocumented and owned by the Looker UI.",
+			},
+			"personal_folder_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the personal folder of the user.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full name of the user, available when both the first and the last name are set.",
+			},
+			"has_credentials_saml": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has SAML credentials.",
+			},
+			"has_credentials_google": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has Google credentials.",
+			},
+			"has_credentials_ldap": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has LDAP credentials.",
+			},
+			"has_credentials_oidc": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user has OpenID Connect credentials.",
+			},
+			"role_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the roles of the user, including the roles inherited from groups.",
+			},
+			"group_ids": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the groups of the user.",
+			},
+			"presumed_looker_employee": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is presumed to be a Looker employee.",
+			},
 		},
 	}
 }
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
-	firstName := d.Get("first_name").(string)
-	lastName := d.Get("last_name").(string)
+	client := m.(*lookerClient)
 	email := d.Get("email").(string)
-	isDisabled := d.Get("is_disabled").(bool)
 
-	writeUser := apiclient.WriteUser{
-		FirstName:  &firstName,
-		LastName:   &lastName,
-		IsDisabled: &isDisabled,
+	writeUser, err := expandWriteUser(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
 
-	// CreateUser sometimes returns 500 error
+	// CreateUser sometimes fails with a transient 500, which the API transport doesn't retry for POST requests
 	var user apiclient.User
-	err := resource.RetryContext(ctx, 1*time.Minute, func() *resource.RetryError {
+	err = resource.RetryContext(ctx, 1*time.Minute, func() *resource.RetryError {
 		var err error
 
 		user, err = client.CreateUser(writeUser, "", nil)
 		if err != nil {
-			if d.IsNewResource() && strings.Contains(err.Error(), "500") {
+			if d.IsNewResource() && apiStatusCode(err) == http.StatusInternalServerError {
 				return resource.RetryableError(err)
 			}
 			return resource.NonRetryableError(err)
@@ -86,9 +181,10 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	_, err = client.CreateUserCredentialsEmail(userID, writeCredentialsEmail, "", nil)
 	if err != nil {
-		if _, err = client.DeleteUser(userID, nil); err != nil {
-			return diag.FromErr(wrapSDKError(err, "DeleteUser", "user", "email=%s, id=%s", email, userID))
+		if _, deleteErr := client.DeleteUser(userID, nil); deleteErr != nil {
+			return diag.FromErr(wrapSDKError(deleteErr, "DeleteUser", "user", "email=%s, id=%s", email, userID))
 		}
+		d.SetId("")
 		return diag.FromErr(wrapSDKError(err, "CreateUserCredentialsEmail", "user", "%s", email))
 	}
 
@@ -108,16 +204,25 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 
 	user, err := client.User(userID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "User", "user", "%s", userID))
 	}
 
-	if err = d.Set("email", user.Email); err != nil {
+	// the email address lives on the email credential, which can be changed or removed outside of Terraform
+	var email string
+	if user.CredentialsEmail != nil {
+		email = valueOrZero(user.CredentialsEmail.Email)
+	}
+	if err = d.Set("email", email); err != nil {
 		return diag.FromErr(err)
 	}
 	if err = d.Set("first_name", user.FirstName); err != nil {
@@ -129,55 +234,304 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	if err = d.Set("is_disabled", user.IsDisabled); err != nil {
 		return diag.FromErr(err)
 	}
+	if err = d.Set("locale", user.Locale); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("home_folder_id", user.HomeFolderId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("models_dir_validated", user.ModelsDirValidated); err != nil {
+		return diag.FromErr(err)
+	}
+	uiState, err := flattenUIState(user.UiState)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("ui_state", uiState); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("personal_folder_id", user.PersonalFolderId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("display_name", user.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("has_credentials_saml", user.CredentialsSaml != nil); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("has_credentials_google", user.CredentialsGoogle != nil); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("has_credentials_ldap", user.CredentialsLdap != nil); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("has_credentials_oidc", user.CredentialsOidc != nil); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("role_ids", valueOrZero(user.RoleIds)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("group_ids", valueOrZero(user.GroupIds)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("presumed_looker_employee", user.PresumedLookerEmployee); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
+// expandWriteUser builds the user from the configuration. The optional attributes Looker has a value for
+// are only sent when they are set.
+func expandWriteUser(d *schema.ResourceData) (apiclient.WriteUser, error) {
+	firstName := d.Get("first_name").(string)
+	lastName := d.Get("last_name").(string)
+	isDisabled := d.Get("is_disabled").(bool)
+
+	writeUser := apiclient.WriteUser{
+		FirstName:  &firstName,
+		LastName:   &lastName,
+		IsDisabled: &isDisabled,
+	}
+	if v, ok := d.GetOk("locale"); ok {
+		locale := v.(string)
+		writeUser.Locale = &locale
+	}
+	if v, ok := d.GetOk("home_folder_id"); ok {
+		homeFolderID := v.(string)
+		writeUser.HomeFolderId = &homeFolderID
+	}
+	if v, ok := d.GetOkExists("models_dir_validated"); ok { //nolint:staticcheck
+		modelsDirValidated := v.(bool)
+		writeUser.ModelsDirValidated = &modelsDirValidated
+	}
+	if v, ok := d.GetOk("ui_state"); ok {
+		var uiState map[string]interface{}
+		if err := json.Unmarshal([]byte(v.(string)), &uiState); err != nil {
+			return writeUser, fmt.Errorf("ui_state must be a JSON object: %w", err)
+		}
+		writeUser.UiState = &uiState
+	}
+	return writeUser, nil
+}
+
+// flattenUIState encodes the UI state as JSON, or returns an empty string when the user has none.
+func flattenUIState(uiState *map[string]interface{}) (string, error) {
+	if uiState == nil || *uiState == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(*uiState)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode ui_state: %w", err)
+	}
+	return string(b), nil
+}
+
 func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
+	email := d.Get("email").(string)
 
-	if d.HasChanges("first_name", "last_name", "is_disabled") {
-		firstName := d.Get("first_name").(string)
-		lastName := d.Get("last_name").(string)
-		isDisabled := d.Get("is_disabled").(bool)
-		email := d.Get("email").(string)
-		writeUser := apiclient.WriteUser{
-			FirstName:  &firstName,
-			LastName:   &lastName,
-			IsDisabled: &isDisabled,
-		}
-		_, err := client.UpdateUser(userID, writeUser, "", nil)
-		if err != nil {
-			return diag.FromErr(wrapSDKError(err, "UpdateUser", "user", "email=%s, id=%s", email, userID))
+	// keep the prior state when the update fails
+	d.Partial(true)
+
+	// the email credential is changed first, so that it can be rolled back if updating the user fails
+	emailChanged := d.HasChange("email")
+	oldEmail, _ := d.GetChange("email")
+	if emailChanged {
+		if err := setUserEmail(client, userID, email); err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
-	if d.HasChange("email") {
-		email := d.Get("email").(string)
-		writeCredentialsEmail := apiclient.WriteCredentialsEmail{
-			Email: &email,
+	if d.HasChanges("first_name", "last_name", "is_disabled", "locale", "home_folder_id", "models_dir_validated", "ui_state") {
+		writeUser, err := expandWriteUser(d)
+		if err != nil {
+			return diag.FromErr(err)
 		}
-		_, err := client.UpdateUserCredentialsEmail(userID, writeCredentialsEmail, "", nil)
+		_, err = client.UpdateUser(userID, writeUser, "", nil)
 		if err != nil {
-			return diag.FromErr(wrapSDKError(err, "UpdateUserCredentialsEmail", "user", "email=%s, id=%s", email, userID))
+			diags := diag.FromErr(wrapSDKError(err, "UpdateUser", "user", "email=%s, id=%s", email, userID))
+			if emailChanged {
+				if rollbackErr := rollbackUserEmail(client, userID, oldEmail.(string)); rollbackErr != nil {
+					diags = append(diags, diag.Errorf("failed to roll back the email address of the user to %q: %v", oldEmail, rollbackErr)...)
+				}
+			}
+			return diags
 		}
 	}
 
+	d.Partial(false)
+
 	return resourceUserRead(ctx, d, m)
 }
 
+// setUserEmail changes the email address of the user, creating the email credential if the user has none.
+func setUserEmail(client *lookerClient, userID, email string) error {
+	writeCredentialsEmail := apiclient.WriteCredentialsEmail{
+		Email: &email,
+	}
+	_, err := client.UpdateUserCredentialsEmail(userID, writeCredentialsEmail, "", nil)
+	if isNotFound(err) {
+		_, err = client.CreateUserCredentialsEmail(userID, writeCredentialsEmail, "", nil)
+		if err != nil {
+			return wrapSDKError(err, "CreateUserCredentialsEmail", "user", "email=%s, id=%s", email, userID)
+		}
+		return nil
+	}
+	if err != nil {
+		return wrapSDKError(err, "UpdateUserCredentialsEmail", "user", "email=%s, id=%s", email, userID)
+	}
+	return nil
+}
+
+// rollbackUserEmail restores the prior email address of the user, deleting the email credential if the user had none.
+func rollbackUserEmail(client *lookerClient, userID, oldEmail string) error {
+	if oldEmail != "" {
+		return setUserEmail(client, userID, oldEmail)
+	}
+	if _, err := client.DeleteUserCredentialsEmail(userID, nil); err != nil {
+		return wrapSDKError(err, "DeleteUserCredentialsEmail", "user", "id=%s", userID)
+	}
+	return nil
+}
+
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 	email := d.Get("email").(string)
+	deletionMode := d.Get("deletion_mode").(string)
+
+	if deletionMode == userDeletionModeAbandon {
+		return nil
+	}
+
+	if folderID := d.Get("content_transfer_folder_id").(string); folderID != "" {
+		if err := transferPersonalFolder(ctx, client, userID, folderID); err != nil {
+			return diagsFromErr(err)
+		}
+	}
+
+	if deletionMode == userDeletionModeDisable {
+		isDisabled := true
+		_, err := client.UpdateUser(userID, apiclient.WriteUser{IsDisabled: &isDisabled}, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				return nil
+			}
+			return diag.FromErr(wrapSDKError(err, "UpdateUser", "user", "email=%s, id=%s", email, userID))
+		}
+		return nil
+	}
 
 	_, err := client.DeleteUser(userID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteUser", "user", "email=%s, id=%s", email, userID))
 	}
 
 	return nil
 }
+
+// transferPersonalFolder moves the folders, looks and dashboards of the personal folder of the user to the folder.
+func transferPersonalFolder(ctx context.Context, client *lookerClient, userID, folderID string) error {
+	user, err := client.User(userID, "personal_folder_id", nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return wrapSDKError(err, "User", "user", "%s", userID)
+	}
+	personalFolderID := valueOrZero(user.PersonalFolderId)
+	if personalFolderID == "" {
+		return nil
+	}
+
+	fields := "id"
+	children, err := paginate(defaultPageSize, func(limit, offset int64) ([]apiclient.Folder, error) {
+		return client.FolderChildren(apiclient.RequestFolderChildren{
+			FolderId: personalFolderID,
+			Fields:   &fields,
+			Limit:    &limit,
+			Offset:   &offset,
+		}, nil)
+	})
+	if err != nil {
+		return wrapSDKError(err, "FolderChildren", "user", "%s", personalFolderID)
+	}
+	looks, err := client.FolderLooks(personalFolderID, fields, nil)
+	if err != nil {
+		return wrapSDKError(err, "FolderLooks", "user", "%s", personalFolderID)
+	}
+	dashboards, err := client.FolderDashboards(personalFolderID, fields, nil)
+	if err != nil {
+		return wrapSDKError(err, "FolderDashboards", "user", "%s", personalFolderID)
+	}
+
+	errChildren := forEachParallel(ctx, client.bulkConcurrency, children, func(child apiclient.Folder) error {
+		_, err := client.UpdateFolder(valueOrZero(child.Id), apiclient.UpdateFolder{ParentId: &folderID}, nil)
+		return wrapSDKError(err, "UpdateFolder", "user", "folder=%s, target=%s", valueOrZero(child.Id), folderID)
+	})
+	errLooks := forEachParallel(ctx, client.bulkConcurrency, looks, func(look apiclient.LookWithQuery) error {
+		_, err := client.UpdateLook(valueOrZero(look.Id), apiclient.WriteLookWithQuery{FolderId: &folderID}, "", nil)
+		return wrapSDKError(err, "UpdateLook", "user", "look=%s, target=%s", valueOrZero(look.Id), folderID)
+	})
+	errDashboards := forEachParallel(ctx, client.bulkConcurrency, dashboards, func(dashboard apiclient.Dashboard) error {
+		_, err := client.UpdateDashboard(valueOrZero(dashboard.Id), apiclient.WriteDashboard{FolderId: &folderID}, nil)
+		return wrapSDKError(err, "UpdateDashboard", "user", "dashboard=%s, target=%s", valueOrZero(dashboard.Id), folderID)
+	})
+	return errors.Join(errChildren, errLooks, errDashboards)
+}
+
+// resourceUserImport imports a user by ID, or by email address with an ID of the form `email:<address>`.
+func resourceUserImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	email, ok := strings.CutPrefix(d.Id(), "email:")
+	if !ok {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	userID, err := findUserIDByEmail(m.(*lookerClient).LookerSDK, email)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(userID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// findUserIDByEmail returns the ID of the only user with the email address.
+func findUserIDByEmail(client *apiclient.LookerSDK, email string) (string, error) {
+	fields := "id,email"
+	users, err := paginate(defaultPageSize, func(limit, offset int64) ([]apiclient.User, error) {
+		return client.SearchUsers(apiclient.RequestSearchUsers{
+			Fields: &fields,
+			Email:  &email,
+			Limit:  &limit,
+			Offset: &offset,
+		}, nil)
+	})
+	if err != nil {
+		return "", wrapSDKError(err, "SearchUsers", "user", "email=%s", email)
+	}
+
+	// search filters treat % and _ as wildcards, so keep only the exact matches
+	var userIDs []string
+	for _, user := range users {
+		if user.Id != nil && strings.EqualFold(valueOrZero(user.Email), email) {
+			userIDs = append(userIDs, *user.Id)
+		}
+	}
+
+	switch len(userIDs) {
+	case 0:
+		return "", fmt.Errorf("no user has the email address %q", email)
+	case 1:
+		return userIDs[0], nil
+	default:
+		return "", fmt.Errorf("%d users have the email address %q (IDs %s), import the user by ID instead", len(userIDs), email, strings.Join(userIDs, ", "))
+	}
+}
@@ -0,0 +1,135 @@
+package looker
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUserAPICredentials() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an API3 key of a user or a service account.\n\n" +
+			"The client secret is only returned by Looker when the key is created, so it is empty after an import. " +
+			"Change `rotation_trigger` to replace the key with a new one; " +
+			"with `lifecycle { create_before_destroy = true }` the new key is created before the old one is deleted.",
+		CreateContext: resourceUserAPICredentialsCreate,
+		ReadContext:   resourceUserAPICredentialsRead,
+		DeleteContext: resourceUserAPICredentialsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the user or the service account owning the key.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values that replace the key with a new one when they change, e.g. a rotation date.",
+			},
+			"client_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Client ID of the key.",
+			},
+			"client_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Client secret of the key. Only available for keys created by Terraform.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time the key was created.",
+			},
+			"is_disabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the key has been disabled.",
+			},
+		},
+	}
+}
+
+func resourceUserAPICredentialsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	userID := d.Get("user_id").(string)
+
+	creds, err := client.CreateUserCredentialsApi3(userID, "", nil)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "CreateUserCredentialsApi3", "user_api_credentials", "%s", userID))
+	}
+
+	if creds.Id == nil {
+		return diag.Errorf("API credentials ID not returned from API")
+	}
+
+	d.SetId(buildTwoPartID(&userID, creds.Id))
+
+	// the secret can't be read back, so it is only ever set here
+	if err = d.Set("client_secret", valueOrZero(creds.ClientSecret)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUserAPICredentialsRead(ctx, d, m)
+}
+
+func resourceUserAPICredentialsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	userID, credentialsID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	creds, err := client.UserCredentialsApi3(userID, credentialsID, "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "UserCredentialsApi3", "user_api_credentials", "%s:%s", userID, credentialsID))
+	}
+
+	if err = d.Set("user_id", userID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("client_id", creds.ClientId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("created_at", creds.CreatedAt); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("is_disabled", creds.IsDisabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceUserAPICredentialsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	userID, credentialsID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.DeleteUserCredentialsApi3(userID, credentialsID, nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "DeleteUserCredentialsApi3", "user_api_credentials", "%s:%s", userID, credentialsID))
+	}
+
+	return nil
+}
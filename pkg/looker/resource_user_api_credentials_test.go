@@ -0,0 +1,150 @@
+package looker
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_UserAPICredentials(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+	var clientID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: userAPICredentialsConfig(name, "2024-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("looker_user_api_credentials.user", "user_id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttrSet("looker_user_api_credentials.user", "client_id"),
+					resource.TestCheckResourceAttrSet("looker_user_api_credentials.user", "client_secret"),
+					resource.TestCheckResourceAttrPair("looker_user_api_credentials.service_account", "user_id", "looker_service_account.test", "id"),
+					resource.TestCheckResourceAttrSet("looker_user_api_credentials.service_account", "client_secret"),
+					storeAttr("looker_user_api_credentials.user", "client_id", &clientID),
+				),
+			},
+			// Test: Rotate
+			{
+				Config: userAPICredentialsConfig(name, "2024-02"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("looker_user_api_credentials.user", "client_secret"),
+					func(s *terraform.State) error {
+						rotated := s.RootModule().Resources["looker_user_api_credentials.user"].Primary.Attributes["client_id"]
+						if rotated == clientID {
+							return fmt.Errorf("client_id %s was not rotated", clientID)
+						}
+						return nil
+					},
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:            "looker_user_api_credentials.user",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client_secret", "rotation_trigger"},
+			},
+		},
+		CheckDestroy: testAccCheckUserAPICredentialsDestroy,
+	})
+}
+
+func TestResourceUserAPICredentials(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceUserAPICredentials()
+	state := testApply(t, r, client, nil, map[string]interface{}{
+		"user_id": fakelooker.AdminUserID,
+	})
+
+	// the key can log in
+	settings := client.settings
+	settings.ClientId = state.Attributes["client_id"]
+	settings.ClientSecret = state.Attributes["client_secret"]
+	_, err := newClientCredentialsTokenSource(settings, http.DefaultTransport).Token()
+	require.NoError(t, err)
+
+	// the secret, which can't be read back, is kept on refresh
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, settings.ClientSecret, state.Attributes["client_secret"])
+
+	// a deleted key is removed from the state
+	_, credentialsID, err := parseTwoPartID(state.ID)
+	require.NoError(t, err)
+	_, err = client.DeleteUserCredentialsApi3(fakelooker.AdminUserID, credentialsID, nil)
+	require.NoError(t, err)
+	assert.Nil(t, testRefresh(t, r, client, state))
+}
+
+func storeAttr(name, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+func testAccCheckUserAPICredentialsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_user_api_credentials" {
+			continue
+		}
+
+		userID, credentialsID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = client.UserCredentialsApi3(userID, credentialsID, "", nil)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		return fmt.Errorf("user_api_credentials '%s' still exists", rs.Primary.ID)
+	}
+	return nil
+}
+
+func userAPICredentialsConfig(name, rotation string) string {
+	return fmt.Sprintf(`
+	resource "looker_user" "test" {
+		first_name = "%s"
+		last_name  = "%s"
+		email      = "%s@example.com"
+	}
+	resource "looker_service_account" "test" {
+		service_account_name = "%s"
+	}
+	resource "looker_user_api_credentials" "user" {
+		user_id = looker_user.test.id
+		rotation_trigger = {
+			rotated_at = "%s"
+		}
+		lifecycle {
+			create_before_destroy = true
+		}
+	}
+	resource "looker_user_api_credentials" "service_account" {
+		user_id = looker_service_account.test.id
+	}
+	`, name, name, name, name, rotation)
+}
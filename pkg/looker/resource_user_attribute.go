@@ -59,7 +59,7 @@ func resourceUserAttribute() *schema.Resource {
 }
 
 func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 	userAttributeName := d.Get("name").(string)
 	userAttributeLabel := d.Get("label").(string)
 	userAttributeType := d.Get("type").(string)
@@ -97,12 +97,16 @@ func resourceUserAttributeCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceUserAttributeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userAttributeID := d.Id()
 
 	userAttribute, err := client.UserAttribute(userAttributeID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "UserAttribute", "user_attribute", "%s", userAttributeID))
 	}
 
@@ -135,7 +139,7 @@ func resourceUserAttributeRead(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceUserAttributeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userAttributeID := d.Id()
 
@@ -173,7 +177,7 @@ func resourceUserAttributeUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceUserAttributeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userAttributeID := d.Id()
 	userAttributeName := d.Get("name").(string)
@@ -182,6 +186,9 @@ func resourceUserAttributeDelete(ctx context.Context, d *schema.ResourceData, m
 
 	_, err := client.DeleteUserAttribute(userAttributeID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "DeleteUserAttribute", "user_attribute", "name=%s, id=%s", userAttributeName, userAttributeID))
 	}
 
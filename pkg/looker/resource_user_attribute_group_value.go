@@ -37,7 +37,7 @@ func resourceUserAttributeGroupValue() *schema.Resource {
 }
 
 func resourceUserAttributeGroupValueCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID := d.Get("group_id").(string)
 	userAttributeID := d.Get("user_attribute_id").(string)
@@ -65,7 +65,7 @@ func resourceUserAttributeGroupValueCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceUserAttributeGroupValueRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -76,16 +76,25 @@ func resourceUserAttributeGroupValueRead(ctx context.Context, d *schema.Resource
 
 	userAttributeGroupValues, err := client.AllUserAttributeGroupValues(userAttributeID, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "AllUserAttributeGroupValues", "user_attribute_group_value", "%s", userAttributeID))
 	}
 
-	var userAttributeGroupValue apiclient.UserAttributeGroupValue
-	for _, groupValue := range userAttributeGroupValues {
-		if *groupValue.GroupId == groupID {
-			userAttributeGroupValue = groupValue
+	var userAttributeGroupValue *apiclient.UserAttributeGroupValue
+	for i, groupValue := range userAttributeGroupValues {
+		if groupValue.GroupId != nil && *groupValue.GroupId == groupID {
+			userAttributeGroupValue = &userAttributeGroupValues[i]
 			break
 		}
 	}
+	if userAttributeGroupValue == nil {
+		// the value was removed from the group outside of Terraform
+		d.SetId("")
+		return nil
+	}
 
 	if err = d.Set("group_id", userAttributeGroupValue.GroupId); err != nil {
 		return diag.FromErr(err)
@@ -101,7 +110,7 @@ func resourceUserAttributeGroupValueRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceUserAttributeGroupValueUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -126,7 +135,7 @@ func resourceUserAttributeGroupValueUpdate(ctx context.Context, d *schema.Resour
 }
 
 func resourceUserAttributeGroupValueDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	groupID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -137,6 +146,9 @@ func resourceUserAttributeGroupValueDelete(ctx context.Context, d *schema.Resour
 
 	err = client.DeleteUserAttributeGroupValue(groupID, userAttributeID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		log.Printf("[DEBUG] %+v", err)
 		return diag.FromErr(wrapSDKError(err, "DeleteUserAttributeGroupValue", "user_attribute_group_value", "%s:%s", groupID, userAttributeID))
 	}
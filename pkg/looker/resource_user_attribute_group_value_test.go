@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_UserAttributeGroupValue(t *testing.T) {
@@ -63,7 +62,7 @@ func testAccCheckUserAttributeGroupValueExists(n string) resource.TestCheckFunc
 
 		userAttributeID := userAttributeIDString
 
-		client := testAccProvider.Meta().(*apiclient.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 		userAttributeGroupValues, err := client.AllUserAttributeGroupValues(userAttributeID, "", nil)
 		if err != nil {
 			return err
@@ -78,7 +77,7 @@ func testAccCheckUserAttributeGroupValueExists(n string) resource.TestCheckFunc
 }
 
 func testAccCheckUserAttributeGroupValueDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_user_attribute_group_value" {
@@ -92,7 +91,7 @@ func testAccCheckUserAttributeGroupValueDestroy(s *terraform.State) error {
 
 		userAttributeGroupValues, err := client.AllUserAttributeGroupValues(userAttributeID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
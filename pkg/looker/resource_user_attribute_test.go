@@ -2,13 +2,11 @@ package looker
 
 import (
 	"fmt"
-	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
 )
 
 func TestAcc_UserAttribute(t *testing.T) {
@@ -98,7 +96,7 @@ func testAccCheckUserAttributeExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("no user attribute setting ID is set")
 		}
 
-		client := testAccProvider.Meta().(*apiclient.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 		userAttribute, err := client.UserAttribute(rs.Primary.ID, "", nil)
 		if err != nil {
 			return err
@@ -113,7 +111,7 @@ func testAccCheckUserAttributeExists(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckUserAttributeDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_user_attribute" {
@@ -122,7 +120,7 @@ func testAccCheckUserAttributeDestroy(s *terraform.State) error {
 
 		userAttribute, err := client.UserAttribute(rs.Primary.ID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
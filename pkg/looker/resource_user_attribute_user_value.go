@@ -38,7 +38,7 @@ func resourceUserAttributeUserValue() *schema.Resource {
 }
 
 func resourceUserAttributeUserValueCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 	userID := d.Get("user_id").(string)
 	userAttributeID := d.Get("user_attribute_id").(string)
 	userAttributeValue := d.Get("value").(string)
@@ -62,7 +62,7 @@ func resourceUserAttributeUserValueCreate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceUserAttributeUserValueRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -77,10 +77,19 @@ func resourceUserAttributeUserValueRead(ctx context.Context, d *schema.ResourceD
 
 	userAttributeUserValues, err := client.UserAttributeUserValues(request, nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "UserAttributeUserValues", "user_attribute_user_value", "%s:%s", userID, userAttributeID))
 	}
+	if len(userAttributeUserValues) == 0 {
+		// the value was removed from the user outside of Terraform
+		d.SetId("")
+		return nil
+	}
 	if len(userAttributeUserValues) != 1 { // the number of the result should be one
-		return diag.FromErr(err)
+		return diag.Errorf("expected one value of user attribute %s for user %s, got %d", userAttributeID, userID, len(userAttributeUserValues))
 	}
 
 	if err = d.Set("user_id", userAttributeUserValues[0].UserId); err != nil {
@@ -97,7 +106,7 @@ func resourceUserAttributeUserValueRead(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceUserAttributeUserValueUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -118,7 +127,7 @@ func resourceUserAttributeUserValueUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceUserAttributeUserValueDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID, userAttributeID, err := parseTwoPartID(d.Id())
 	if err != nil {
@@ -129,6 +138,9 @@ func resourceUserAttributeUserValueDelete(ctx context.Context, d *schema.Resourc
 
 	err = client.DeleteUserAttributeUserValue(userID, userAttributeID, nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		log.Printf("[DEBUG] %+v", err)
 		return diag.FromErr(wrapSDKError(err, "DeleteUserAttributeUserValue", "user_attribute_user_value", "%s:%s", userID, userAttributeID))
 	}
@@ -58,7 +58,7 @@ func testAccCheckUserAttributeUserValueExists(n string) resource.TestCheckFunc {
 			return fmt.Errorf("no user attribute user value setting ID is set")
 		}
 
-		client := testAccProvider.Meta().(*apiclient.LookerSDK)
+		client := testAccProvider.Meta().(*lookerClient)
 		userID, userAttributeID, err := parseTwoPartID(rs.Primary.ID)
 		if err != nil {
 			return err
@@ -80,7 +80,7 @@ func testAccCheckUserAttributeUserValueExists(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckUserAttributeUserValueDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_user_attribute_user_value" {
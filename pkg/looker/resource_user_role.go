@@ -0,0 +1,145 @@
+package looker
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+)
+
+func resourceUserRole() *schema.Resource {
+	return &schema.Resource{
+		Description: "Assigns a single role to a user, keeping the roles assigned to the user elsewhere, for example by SAML or LDAP. " +
+			"Do not use it together with looker_user_roles for the same user, which removes the roles it does not manage.",
+		CreateContext: resourceUserRoleCreate,
+		ReadContext:   resourceUserRoleRead,
+		DeleteContext: resourceUserRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"role_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceUserRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+	userID := d.Get("user_id").(string)
+	roleID := d.Get("role_id").(string)
+
+	unlock := client.lock("user_roles:" + userID)
+	defer unlock()
+
+	roleIDs, err := directUserRoleIDs(client.LookerSDK, userID)
+	if err != nil {
+		return diag.FromErr(wrapSDKError(err, "UserRoles", "user_role", "%s:%s", userID, roleID))
+	}
+
+	if !contains(roleIDs, roleID) {
+		_, err = client.SetUserRoles(userID, append(roleIDs, roleID), "", nil)
+		if err != nil {
+			return diag.FromErr(wrapSDKError(err, "SetUserRoles", "user_role", "%s:%s", userID, roleID))
+		}
+	}
+
+	d.SetId(buildTwoPartID(&userID, &roleID))
+
+	return resourceUserRoleRead(ctx, d, m)
+}
+
+func resourceUserRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	userID, roleID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	roleIDs, err := directUserRoleIDs(client.LookerSDK, userID)
+	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "UserRoles", "user_role", "%s:%s", userID, roleID))
+	}
+	if !contains(roleIDs, roleID) {
+		// the role was removed from the user outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("user_id", userID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("role_id", roleID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceUserRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*lookerClient)
+
+	userID, roleID, err := parseTwoPartID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	unlock := client.lock("user_roles:" + userID)
+	defer unlock()
+
+	roleIDs, err := directUserRoleIDs(client.LookerSDK, userID)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "UserRoles", "user_role", "%s:%s", userID, roleID))
+	}
+	if !contains(roleIDs, roleID) {
+		return nil
+	}
+
+	_, err = client.SetUserRoles(userID, slices.DeleteFunc(roleIDs, func(id string) bool { return id == roleID }), "", nil)
+	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(wrapSDKError(err, "SetUserRoles", "user_role", "%s:%s", userID, roleID))
+	}
+
+	return nil
+}
+
+// directUserRoleIDs returns the IDs of the roles assigned to the user directly, leaving out the ones
+// the user only has through its groups, which must not be turned into direct assignments.
+func directUserRoleIDs(client *apiclient.LookerSDK, userID string) ([]string, error) {
+	directAssociationOnly := true
+	roles, err := client.UserRoles(apiclient.RequestUserRoles{
+		UserId:                userID,
+		DirectAssociationOnly: &directAssociationOnly,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleIDs = append(roleIDs, *role.Id)
+	}
+	return roleIDs, nil
+}
@@ -0,0 +1,178 @@
+package looker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcc_UserRole(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			// Test: Create
+			{
+				Config: userRoleConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserRoleAssigned("looker_user_role.test1"),
+					testAccCheckUserRoleAssigned("looker_user_role.test2"),
+					resource.TestCheckResourceAttrPair("looker_user_role.test1", "user_id", "looker_user.test", "id"),
+					resource.TestCheckResourceAttrPair("looker_user_role.test1", "role_id", "looker_role.test1", "id"),
+				),
+			},
+			// Test: Import
+			{
+				ResourceName:      "looker_user_role.test1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+		CheckDestroy: testAccCheckUserRoleUnassigned,
+	})
+}
+
+func TestResourceUserRole_Concurrent(t *testing.T) {
+	_, client := newTestClient(t)
+
+	firstName := "Jane"
+	user, err := client.CreateUser(apiclient.WriteUser{FirstName: &firstName}, "", nil)
+	require.NoError(t, err)
+
+	var roleIDs []string
+	for i := 0; i < 10; i++ {
+		roleID := createTestRole(t, client, fmt.Sprintf("role%d", i))
+		roleIDs = append(roleIDs, roleID)
+	}
+
+	// every resource adding its own role to the same user must not drop the roles added by the others
+	r := resourceUserRole()
+	var wg sync.WaitGroup
+	for _, roleID := range roleIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"user_id": *user.Id, "role_id": roleID})
+			diags := r.CreateContext(context.Background(), d, client)
+			assert.False(t, diags.HasError(), "%v", diags)
+		}()
+	}
+	wg.Wait()
+
+	assigned, err := directUserRoleIDs(client.LookerSDK, *user.Id)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, roleIDs, assigned)
+}
+
+func createTestRole(t *testing.T, client *lookerClient, name string) string {
+	t.Helper()
+
+	permissionSet, err := client.CreatePermissionSet(apiclient.WritePermissionSet{Name: &name, Permissions: &[]string{"access_data"}}, nil)
+	require.NoError(t, err)
+	modelSet, err := client.CreateModelSet(apiclient.WriteModelSet{Name: &name, Models: &[]string{"test"}}, nil)
+	require.NoError(t, err)
+	role, err := client.CreateRole(apiclient.WriteRole{Name: &name, PermissionSetId: permissionSet.Id, ModelSetId: modelSet.Id}, nil)
+	require.NoError(t, err)
+	return *role.Id
+}
+
+func testAccCheckUserRoleAssigned(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("user role not found from resources: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*lookerClient)
+		userID, roleID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		roleIDs, err := directUserRoleIDs(client.LookerSDK, userID)
+		if err != nil {
+			return err
+		}
+		if !contains(roleIDs, roleID) {
+			return fmt.Errorf("role %s is not assigned to user %s", roleID, userID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckUserRoleUnassigned(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_user_role" {
+			continue
+		}
+
+		userID, roleID, err := parseTwoPartID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		roleIDs, err := directUserRoleIDs(client.LookerSDK, userID)
+		if err != nil {
+			if isNotFound(err) {
+				continue // successfully destroyed
+			}
+			return err
+		}
+
+		if contains(roleIDs, roleID) {
+			return fmt.Errorf("user_role '%s' still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func userRoleConfig(name string) string {
+	return fmt.Sprintf(`
+	resource "looker_user" "test" {
+        first_name = "%s"
+        last_name  = "%s"
+		email      = "%s@example.com"
+	}
+	resource "looker_model_set" "test" {
+		name = "%s"
+		models = ["test"]
+	}
+	resource "looker_permission_set" "test" {
+		name = "%s"
+		permissions = ["access_data"]
+	}
+	resource "looker_role" "test1" {
+		name = "%s_1"
+		permission_set_id = looker_permission_set.test.id
+		model_set_id = looker_model_set.test.id
+	}
+	resource "looker_role" "test2" {
+		name = "%s_2"
+		permission_set_id = looker_permission_set.test.id
+		model_set_id = looker_model_set.test.id
+	}
+	resource "looker_user_role" "test1" {
+		user_id = looker_user.test.id
+		role_id = looker_role.test1.id
+	}
+	resource "looker_user_role" "test2" {
+		user_id = looker_user.test.id
+		role_id = looker_role.test2.id
+	}
+	`, name, name, name, name, name, name, name)
+}
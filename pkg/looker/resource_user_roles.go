@@ -33,7 +33,7 @@ func resourceUserRoles() *schema.Resource {
 }
 
 func resourceUserRolesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Get("user_id").(string)
 
@@ -54,7 +54,7 @@ func resourceUserRolesCreate(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 
@@ -62,6 +62,10 @@ func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, m interf
 
 	userRoles, err := client.UserRoles(request, nil)
 	if err != nil {
+		if isNotFound(err) {
+			d.SetId("")
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "UserRoles", "user_roles", "%s", userID))
 	}
 
@@ -83,7 +87,7 @@ func resourceUserRolesRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 
@@ -102,13 +106,16 @@ func resourceUserRolesUpdate(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceUserRolesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*apiclient.LookerSDK)
+	client := m.(*lookerClient)
 
 	userID := d.Id()
 
 	roleIDs := []string{}
 	_, err := client.SetUserRoles(userID, roleIDs, "", nil)
 	if err != nil {
+		if isNotFound(err) {
+			return nil
+		}
 		return diag.FromErr(wrapSDKError(err, "SetUserRoles", "user_roles", "%s", userID))
 	}
 
@@ -35,7 +35,7 @@ func TestAcc_UserRoles(t *testing.T) {
 }
 
 func testAccCheckUserRoleDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_user_roles" {
@@ -48,7 +48,7 @@ func testAccCheckUserRoleDestroy(s *terraform.State) error {
 
 		userRoles, err := client.UserRoles(request, nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
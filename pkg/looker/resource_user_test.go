@@ -1,14 +1,21 @@
 package looker
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	"github.com/looker-open-source/sdk-codegen/go/rtl"
 	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAcc_User(t *testing.T) {
@@ -25,6 +32,9 @@ func TestAcc_User(t *testing.T) {
 					resource.TestCheckResourceAttr("looker_user.test", "last_name", name),
 					resource.TestCheckResourceAttr("looker_user.test", "email", fmt.Sprintf("%s@example.com", name)),
 					resource.TestCheckResourceAttr("looker_user.test", "send_setup_link_on_create", "false"),
+					resource.TestCheckResourceAttr("looker_user.test", "display_name", fmt.Sprintf("%s %s", name, name)),
+					resource.TestCheckResourceAttrSet("looker_user.test", "personal_folder_id"),
+					resource.TestCheckResourceAttr("looker_user.test", "has_credentials_saml", "false"),
 				),
 			},
 			{
@@ -32,11 +42,215 @@ func TestAcc_User(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "looker_user.test",
+				ImportState:       true,
+				ImportStateId:     fmt.Sprintf("email:%s@example.com", strings.ToLower(name)),
+				ImportStateVerify: true,
+			},
+			{
+				Config: userConfig(name, name, name+"_RENAMED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test", "email", fmt.Sprintf("%s_RENAMED@example.com", name)),
+				),
+			},
 		},
 		CheckDestroy: testAccCheckUserDestroy,
 	})
 }
 
+func TestFindUserIDByEmail(t *testing.T) {
+	_, client := newTestClient(t)
+
+	createUser := func(email string) string {
+		user, err := client.CreateUser(apiclient.WriteUser{}, "", nil)
+		require.NoError(t, err)
+		_, err = client.CreateUserCredentialsEmail(*user.Id, apiclient.WriteCredentialsEmail{Email: &email}, "", nil)
+		require.NoError(t, err)
+		return *user.Id
+	}
+	jane := createUser("jane_doe@example.com")
+	createUser("janexdoe@example.com")
+
+	tests := map[string]struct {
+		email   string
+		wantID  string
+		wantErr string
+	}{
+		"exact match": {
+			email:  "jane_doe@example.com",
+			wantID: jane,
+		},
+		"case-insensitive match": {
+			email:  "Jane_Doe@Example.com",
+			wantID: jane,
+		},
+		"no match": {
+			email:   "john@example.com",
+			wantErr: `no user has the email address "john@example.com"`,
+		},
+		"wildcard matches are ignored": {
+			email:   "jane%@example.com",
+			wantErr: `no user has the email address "jane%@example.com"`,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			userID, err := findUserIDByEmail(client.LookerSDK, tt.email)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantID, userID)
+		})
+	}
+}
+
+func TestFindUserIDByEmail_MultipleMatches(t *testing.T) {
+	// credentials of different types can carry the same email address
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":"3","email":"jane@example.com"},{"id":"7","email":"jane@example.com"}]`))
+	}))
+	defer srv.Close()
+
+	settings := rtl.ApiSettings{BaseUrl: srv.URL, ApiVersion: defaultAPIVersion}
+	client := newLookerClient(settings, newAccessTokenSource("token"), http.DefaultTransport, "")
+
+	_, err := findUserIDByEmail(client.LookerSDK, "jane@example.com")
+	assert.EqualError(t, err, `2 users have the email address "jane@example.com" (IDs 3, 7), import the user by ID instead`)
+}
+
+func TestResourceUserUpdateEmail(t *testing.T) {
+	transport := &failingTransport{base: http.DefaultTransport}
+	_, client := newTestClientWithTransport(t, transport)
+
+	r := resourceUser()
+	apply := func(state *terraform.InstanceState, config map[string]interface{}) (*terraform.InstanceState, error) {
+		t.Helper()
+		state, diags := r.Apply(context.Background(), state, testDiff(t, r, client, state, config), client)
+		if diags.HasError() {
+			return state, fmt.Errorf("%v", diags)
+		}
+		return state, nil
+	}
+	credentialEmail := func(userID string) string {
+		t.Helper()
+		user, err := client.User(userID, "", nil)
+		require.NoError(t, err)
+		if user.CredentialsEmail == nil {
+			return ""
+		}
+		return valueOrZero(user.CredentialsEmail.Email)
+	}
+
+	state, err := apply(nil, map[string]interface{}{
+		"email":      "before@example.com",
+		"first_name": "Jane",
+	})
+	require.NoError(t, err)
+	userID := state.ID
+
+	// the email is changed in place
+	state, err = apply(state, map[string]interface{}{
+		"email":      "after@example.com",
+		"first_name": "Jane",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, userID, state.ID)
+	assert.Equal(t, "after@example.com", state.Attributes["email"])
+	assert.Equal(t, "after@example.com", credentialEmail(userID))
+
+	// the email change is rolled back when updating the user fails
+	transport.failing = http.MethodPatch + " /api/4.0/users/" + userID
+	failed, err := apply(state, map[string]interface{}{
+		"email":      "rolled-back@example.com",
+		"first_name": "John",
+	})
+	require.Error(t, err)
+	assert.Equal(t, "after@example.com", failed.Attributes["email"])
+	assert.Equal(t, "after@example.com", credentialEmail(userID))
+	transport.failing = ""
+
+	// changes made outside of Terraform are detected on read
+	drifted := "drifted@example.com"
+	_, err = client.UpdateUserCredentialsEmail(userID, apiclient.WriteCredentialsEmail{Email: &drifted}, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "drifted@example.com", testRefresh(t, r, client, state).Attributes["email"])
+
+	_, err = client.DeleteUserCredentialsEmail(userID, nil)
+	require.NoError(t, err)
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "", state.Attributes["email"])
+
+	// without a prior email, the email credential is deleted again when updating the user fails
+	transport.failing = http.MethodPatch + " /api/4.0/users/" + userID
+	failed, err = apply(state, map[string]interface{}{
+		"email":      "rolled-back@example.com",
+		"first_name": "John",
+	})
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "failed to roll back")
+	assert.Equal(t, "", failed.Attributes["email"])
+	assert.Equal(t, "", credentialEmail(userID))
+	transport.failing = ""
+
+	// and a removed email credential is created again
+	state, err = apply(state, map[string]interface{}{
+		"email":      "after@example.com",
+		"first_name": "Jane",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "after@example.com", state.Attributes["email"])
+	assert.Equal(t, "after@example.com", credentialEmail(userID))
+}
+
+func TestResourceUserCreate_Retry(t *testing.T) {
+	transport := &failingTransport{base: http.DefaultTransport}
+	_, client := newTestClientWithTransport(t, transport)
+
+	r := resourceUser()
+	config := map[string]interface{}{
+		"email":      "jane@example.com",
+		"first_name": "Jane",
+	}
+
+	// the transient 500 of CreateUser is retried
+	transport.failing, transport.times = http.MethodPost+" /api/4.0/users", 1
+	state := testApply(t, r, client, nil, config)
+	assert.Equal(t, "jane@example.com", state.Attributes["email"])
+	assert.Empty(t, transport.failing)
+}
+
+// failingTransport fails the requests matching failing, a method and a path separated by a space.
+// When times is set, only that many requests fail.
+type failingTransport struct {
+	base    http.RoundTripper
+	failing string
+	times   int
+}
+
+func (f *failingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method+" "+req.URL.Path != f.failing {
+		return f.base.RoundTrip(req)
+	}
+	if f.times > 0 {
+		f.times--
+		if f.times == 0 {
+			f.failing = ""
+		}
+	}
+	return &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Status:     "500 Internal Server Error",
+		Header:     http.Header{},
+		Body:       http.NoBody,
+		Request:    req,
+	}, nil
+}
+
 func TestAcc_UserWithSetupMail(t *testing.T) {
 	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
 
@@ -58,8 +272,50 @@ func TestAcc_UserWithSetupMail(t *testing.T) {
 	})
 }
 
+func TestAcc_UserDeletionModeDisable(t *testing.T) {
+	name := strings.ToUpper(acctest.RandStringFromCharSet(10, acctest.CharSetAlpha))
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: userConfigWithDeletionMode(name, "disable"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("looker_user.test", "deletion_mode", "disable"),
+					resource.TestCheckResourceAttrPair("looker_user.test", "content_transfer_folder_id", "looker_folder.test", "id"),
+				),
+			},
+		},
+		CheckDestroy: testAccCheckUserDisabled,
+	})
+}
+
+// testAccCheckUserDisabled checks that the users were disabled instead of deleted, and deletes them.
+func testAccCheckUserDisabled(s *terraform.State) error {
+	client := testAccProvider.Meta().(*lookerClient)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "looker_user" {
+			continue
+		}
+
+		user, err := client.User(rs.Primary.ID, "", nil)
+		if err != nil {
+			return err
+		}
+		if !*user.IsDisabled {
+			return fmt.Errorf("user '%s' is not disabled", rs.Primary.ID)
+		}
+		if _, err = client.DeleteUser(rs.Primary.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func testAccCheckUserDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*apiclient.LookerSDK)
+	client := testAccProvider.Meta().(*lookerClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "looker_user" {
@@ -70,7 +326,7 @@ func testAccCheckUserDestroy(s *terraform.State) error {
 
 		user, err := client.User(userID, "", nil)
 		if err != nil {
-			if strings.Contains(err.Error(), "404") {
+			if isNotFound(err) {
 				return nil // successfully destroyed
 			}
 			return err
@@ -93,6 +349,22 @@ func userConfig(firstName, lastName, email string) string {
 	`, firstName, lastName, email)
 }
 
+func userConfigWithDeletionMode(name, deletionMode string) string {
+	return fmt.Sprintf(`
+	resource "looker_folder" "test" {
+		name      = "%s"
+		parent_id = "1"
+	}
+	resource "looker_user" "test" {
+		first_name                 = "%s"
+		last_name                  = "%s"
+		email                      = "%s@example.com"
+		deletion_mode              = "%s"
+		content_transfer_folder_id = looker_folder.test.id
+	}
+	`, name, name, name, name, deletionMode)
+}
+
 func userConfigWithSetupMail(firstName, lastName, email string) string {
 	return fmt.Sprintf(`
 	resource "looker_user" "test" {
@@ -103,3 +375,147 @@ func userConfigWithSetupMail(firstName, lastName, email string) string {
 	}
 	`, firstName, lastName, email)
 }
+
+func TestResourceUserDelete(t *testing.T) {
+	_, client := newTestClient(t)
+
+	tests := map[string]struct {
+		deletionMode    string
+		transfer        bool
+		wantDeleted     bool
+		wantDisabled    bool
+		wantTransferred bool
+	}{
+		"delete": {
+			deletionMode: userDeletionModeDelete,
+			wantDeleted:  true,
+		},
+		"disable": {
+			deletionMode: userDeletionModeDisable,
+			wantDisabled: true,
+		},
+		"abandon": {
+			deletionMode: userDeletionModeAbandon,
+		},
+		"delete after transferring the personal folder": {
+			deletionMode:    userDeletionModeDelete,
+			transfer:        true,
+			wantDeleted:     true,
+			wantTransferred: true,
+		},
+		"disable after transferring the personal folder": {
+			deletionMode:    userDeletionModeDisable,
+			transfer:        true,
+			wantDisabled:    true,
+			wantTransferred: true,
+		},
+		"abandon doesn't transfer the personal folder": {
+			deletionMode: userDeletionModeAbandon,
+			transfer:     true,
+		},
+	}
+
+	r := resourceUser()
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			name := acctest.RandStringFromCharSet(10, acctest.CharSetAlpha)
+			target, err := client.CreateFolder(apiclient.CreateFolder{Name: name, ParentId: fakelooker.SharedFolderID}, nil)
+			require.NoError(t, err)
+
+			config := map[string]interface{}{
+				"email":         name + "@example.com",
+				"deletion_mode": tt.deletionMode,
+			}
+			if tt.transfer {
+				config["content_transfer_folder_id"] = *target.Id
+			}
+			state := testApply(t, r, client, nil, config)
+
+			user, err := client.User(state.ID, "", nil)
+			require.NoError(t, err)
+			personalFolderID := *user.PersonalFolderId
+			child, err := client.CreateFolder(apiclient.CreateFolder{Name: "child", ParentId: personalFolderID}, nil)
+			require.NoError(t, err)
+			query, err := client.CreateQuery(apiclient.WriteQuery{Model: "thelook", View: "orders"}, "", nil)
+			require.NoError(t, err)
+			look, err := client.CreateLook(apiclient.WriteLookWithQuery{Title: &name, FolderId: &personalFolderID, QueryId: query.Id}, "", nil)
+			require.NoError(t, err)
+			dashboard, err := client.CreateDashboard(apiclient.WriteDashboard{Title: &name, FolderId: &personalFolderID}, nil)
+			require.NoError(t, err)
+
+			_, diags := r.Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, client)
+			require.False(t, diags.HasError(), "%v", diags)
+
+			user, err = client.User(state.ID, "", nil)
+			if tt.wantDeleted {
+				assert.True(t, isNotFound(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.wantDisabled, *user.IsDisabled)
+			}
+
+			children, err := client.FolderChildren(apiclient.RequestFolderChildren{FolderId: *target.Id}, nil)
+			require.NoError(t, err)
+			looks, err := client.FolderLooks(*target.Id, "", nil)
+			require.NoError(t, err)
+			dashboards, err := client.FolderDashboards(*target.Id, "", nil)
+			require.NoError(t, err)
+			if tt.wantTransferred {
+				require.Len(t, children, 1)
+				assert.Equal(t, *child.Id, *children[0].Id)
+				require.Len(t, looks, 1)
+				assert.Equal(t, *look.Id, *looks[0].Id)
+				require.Len(t, dashboards, 1)
+				assert.Equal(t, *dashboard.Id, *dashboards[0].Id)
+			} else {
+				assert.Empty(t, children)
+				assert.Empty(t, looks)
+				assert.Empty(t, dashboards)
+			}
+		})
+	}
+}
+
+func TestResourceUserAttributes(t *testing.T) {
+	_, client := newTestClient(t)
+
+	r := resourceUser()
+	config := map[string]interface{}{
+		"email":          "jane@example.com",
+		"first_name":     "Jane",
+		"last_name":      "Doe",
+		"locale":         "ja",
+		"home_folder_id": fakelooker.SharedFolderID,
+		"ui_state":       `{"homepage": {"section": "boards"}, "seen_tour": true}`,
+	}
+	state := testApply(t, r, client, nil, config)
+
+	user, err := client.User(state.ID, "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ja", *user.Locale)
+	assert.Equal(t, fakelooker.SharedFolderID, *user.HomeFolderId)
+	assert.Equal(t, map[string]interface{}{"homepage": map[string]interface{}{"section": "boards"}, "seen_tour": true}, *user.UiState)
+
+	groupName := "Jane's group"
+	group, err := client.CreateGroup(apiclient.WriteGroup{Name: &groupName}, "", nil)
+	require.NoError(t, err)
+	_, err = client.AddGroupUser(*group.Id, apiclient.GroupIdForGroupUserInclusion{UserId: &state.ID}, nil)
+	require.NoError(t, err)
+
+	state = testRefresh(t, r, client, state)
+	assert.Equal(t, "Jane Doe", state.Attributes["display_name"])
+	assert.Equal(t, *user.PersonalFolderId, state.Attributes["personal_folder_id"])
+	assert.Equal(t, "false", state.Attributes["has_credentials_saml"])
+	assert.Equal(t, "false", state.Attributes["presumed_looker_employee"])
+	assert.Equal(t, []string{*group.Id}, expandStringListFromSet(r.Data(state).Get("group_ids")))
+
+	// a differently formatted ui_state is not a change
+	assert.Nil(t, testDiff(t, r, client, state, config))
+
+	// the attributes are updated in place
+	config["locale"] = "en"
+	config["ui_state"] = `{"seen_tour": false}`
+	state = testApply(t, r, client, state, config)
+	assert.Equal(t, "en", state.Attributes["locale"])
+	assert.JSONEq(t, `{"seen_tour": false}`, state.Attributes["ui_state"])
+}
@@ -0,0 +1,208 @@
+package looker
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultMaxRetries   = 4
+	defaultRetryWaitMin = 1  // seconds
+	defaultRetryWaitMax = 30 // seconds
+)
+
+// defaultRetryableStatusCodes are the responses Looker returns when it is throttling or temporarily unavailable.
+var defaultRetryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// retryTransport is an http.RoundTripper that retries requests failing with a retryable status code,
+// waiting with exponential backoff between attempts. A Retry-After header sent by the server takes
+// precedence over the computed backoff.
+type retryTransport struct {
+	base                 http.RoundTripper
+	maxRetries           int
+	waitMin              time.Duration
+	waitMax              time.Duration
+	retryableStatusCodes []int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if deadline, ok := req.Context().Deadline(); ok && time.Until(deadline) < wait {
+			// the request would time out while waiting, so give the caller what we have
+			return resp, err
+		}
+		if resp != nil {
+			// drain the body so that the connection can be reused
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of a single attempt is transient.
+// Network errors are only retried for idempotent methods because the server may have processed the request,
+// and so are the retryable status codes, see isRetryableStatus.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// the body was consumed by this attempt and cannot be replayed
+		return false
+	}
+	if err != nil {
+		return req.Context().Err() == nil && isIdempotent(req.Method)
+	}
+	return slices.Contains(t.retryableStatusCodes, resp.StatusCode) &&
+		isRetryableStatus(req.Method, resp.StatusCode, resp.Header.Get("Retry-After"))
+}
+
+// backoff returns how long to wait before the next attempt.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return wait
+		}
+	}
+
+	wait := t.waitMax
+	if attempt < 32 {
+		if exp := t.waitMin << attempt; exp > 0 && exp < t.waitMax {
+			wait = exp
+		}
+	}
+	// add jitter so that parallel requests throttled together do not retry in lockstep
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int64N(half+1))
+	}
+	return wait
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// limitTransport is an http.RoundTripper that throttles requests to the Looker API. The limiter and
+// the semaphore are shared by every resource and data source, so Terraform's parallelism and loops
+// issuing one call per member cannot flood the instance.
+type limitTransport struct {
+	base http.RoundTripper
+	// limiter caps the request rate. A nil limiter means no rate limit.
+	limiter *rate.Limiter
+	// semaphore caps the number of in-flight requests. A nil semaphore means no concurrency limit.
+	semaphore chan struct{}
+}
+
+func newLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrentRequests int) http.RoundTripper {
+	if requestsPerSecond <= 0 && maxConcurrentRequests <= 0 {
+		return base
+	}
+
+	t := &limitTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), 1)
+	}
+	if maxConcurrentRequests > 0 {
+		t.semaphore = make(chan struct{}, maxConcurrentRequests)
+	}
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	release := func() {}
+	if t.semaphore != nil {
+		select {
+		case t.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		release = sync.OnceFunc(func() { <-t.semaphore })
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	// the request stays in flight until its response body has been consumed
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// releaseOnCloseBody calls release once the wrapped response body is closed.
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
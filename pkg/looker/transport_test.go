@@ -0,0 +1,278 @@
+package looker
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		statuses     []int
+		retryAfter   string
+		maxRetries   int
+		wantStatus   int
+		wantAttempts int32
+	}{
+		"success is not retried": {
+			method:       http.MethodGet,
+			statuses:     []int{200},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 1,
+		},
+		"retryable status is retried until success": {
+			method:       http.MethodGet,
+			statuses:     []int{503, 502, 200},
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 3,
+		},
+		"post with body is retried": {
+			method:       http.MethodPost,
+			statuses:     []int{429, 200},
+			retryAfter:   "0",
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 2,
+		},
+		"post is not retried on gateway errors": {
+			method:       http.MethodPost,
+			statuses:     []int{502, 200},
+			maxRetries:   3,
+			wantStatus:   502,
+			wantAttempts: 1,
+		},
+		"post is not retried when unavailable without retry after": {
+			method:       http.MethodPost,
+			statuses:     []int{503, 200},
+			maxRetries:   3,
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+		"post is retried when unavailable with retry after": {
+			method:       http.MethodPost,
+			statuses:     []int{503, 200},
+			retryAfter:   "0",
+			maxRetries:   3,
+			wantStatus:   200,
+			wantAttempts: 2,
+		},
+		"non retryable status is returned": {
+			method:       http.MethodGet,
+			statuses:     []int{500, 200},
+			maxRetries:   3,
+			wantStatus:   500,
+			wantAttempts: 1,
+		},
+		"gives up after max retries": {
+			method:       http.MethodGet,
+			statuses:     []int{503, 503, 503, 503},
+			maxRetries:   2,
+			wantStatus:   503,
+			wantAttempts: 3,
+		},
+		"retries disabled": {
+			method:       http.MethodGet,
+			statuses:     []int{503, 200},
+			maxRetries:   0,
+			wantStatus:   503,
+			wantAttempts: 1,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := attempts.Add(1)
+				body, _ := io.ReadAll(r.Body)
+				if tt.method == http.MethodPost {
+					assert.Equal(t, "payload", string(body))
+				}
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: &retryTransport{
+				base:                 http.DefaultTransport,
+				maxRetries:           tt.maxRetries,
+				waitMin:              time.Millisecond,
+				waitMax:              10 * time.Millisecond,
+				retryableStatusCodes: defaultRetryableStatusCodes,
+			}}
+			req, err := http.NewRequest(tt.method, srv.URL, strings.NewReader("payload"))
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := &retryTransport{
+		waitMin: time.Second,
+		waitMax: 8 * time.Second,
+	}
+
+	tests := map[string]struct {
+		attempt int
+		header  string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		"first attempt waits around the minimum": {
+			attempt: 0,
+			wantMin: 500 * time.Millisecond,
+			wantMax: time.Second,
+		},
+		"wait doubles with every attempt": {
+			attempt: 2,
+			wantMin: 2 * time.Second,
+			wantMax: 4 * time.Second,
+		},
+		"wait is capped at the maximum": {
+			attempt: 10,
+			wantMin: 4 * time.Second,
+			wantMax: 8 * time.Second,
+		},
+		"retry after takes precedence": {
+			attempt: 0,
+			header:  "20",
+			wantMin: 20 * time.Second,
+			wantMax: 20 * time.Second,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+			actual := transport.backoff(tt.attempt, resp)
+			assert.GreaterOrEqual(t, actual, tt.wantMin)
+			assert.LessOrEqual(t, actual, tt.wantMax)
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		value    string
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		"seconds": {
+			value:    "5",
+			wantWait: 5 * time.Second,
+			wantOK:   true,
+		},
+		"http date": {
+			value:    "Mon, 01 Jan 2024 00:00:30 GMT",
+			wantWait: 30 * time.Second,
+			wantOK:   true,
+		},
+		"http date in the past": {
+			value:    "Sun, 31 Dec 2023 23:59:00 GMT",
+			wantWait: 0,
+			wantOK:   true,
+		},
+		"empty": {
+			value:  "",
+			wantOK: false,
+		},
+		"negative": {
+			value:  "-1",
+			wantOK: false,
+		},
+		"garbage": {
+			value:  "soon",
+			wantOK: false,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			actual, ok := parseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantWait, actual)
+		})
+	}
+}
+
+func TestLimitTransportConcurrency(t *testing.T) {
+	const maxConcurrentRequests = 2
+
+	var inFlight, maxInFlight atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			current := maxInFlight.Load()
+			if n <= current || maxInFlight.CompareAndSwap(current, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 0, maxConcurrentRequests)}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(srv.URL)
+			if assert.NoError(t, err) {
+				resp.Body.Close()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrentRequests))
+}
+
+func TestLimitTransportRate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newLimitTransport(http.DefaultTransport, 50, 0)}
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	// the first request passes immediately, the other five wait 20ms each
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestNewLimitTransportDisabled(t *testing.T) {
+	base := http.DefaultTransport
+	assert.Equal(t, base, newLimitTransport(base, 0, 0))
+}
@@ -34,6 +34,14 @@ func expandStringListFromSet(set interface{}) []string {
 	return strings
 }
 
+func expandStringList(list interface{}) []string {
+	var strings []string
+	for _, v := range list.([]interface{}) {
+		strings = append(strings, v.(string))
+	}
+	return strings
+}
+
 func flattenStringList(strings []string) []interface{} {
 	vs := make([]interface{}, 0, len(strings))
 	for _, v := range strings {
@@ -46,6 +54,14 @@ func flattenStringListToSet(strings []string) *schema.Set {
 	return schema.NewSet(schema.HashString, flattenStringList(strings))
 }
 
+func expandIntListFromSet(set interface{}) []int {
+	var ints []int
+	for _, v := range set.(*schema.Set).List() {
+		ints = append(ints, v.(int))
+	}
+	return ints
+}
+
 // func expandInt64ListFromSet(set interface{}) []int64 {
 // 	var ints []int64
 // 	for _, v := range set.(*schema.Set).List() {
@@ -61,3 +77,17 @@ func hash(val interface{}) string {
 	sha := sha256.Sum256([]byte(val.(string)))
 	return hex.EncodeToString(sha[:])
 }
+
+// valueOrZero dereferences an optional field of an API response, returning the zero value when the field is absent.
+func valueOrZero[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+// ptrTo returns a pointer to v, for the optional fields of API requests.
+func ptrTo[T any](v T) *T {
+	return &v
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBuildTwoPartID(t *testing.T) {
@@ -139,6 +140,28 @@ func TestExpandStringListFromSet(t *testing.T) {
 	}
 }
 
+func TestExpandStringList(t *testing.T) {
+	tests := map[string]struct {
+		input    []interface{}
+		wantList []string
+	}{
+		"order is kept": {
+			input:    []interface{}{"c", "a", "b"},
+			wantList: []string{"c", "a", "b"},
+		},
+		"empty list": {
+			input:    []interface{}{},
+			wantList: nil,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, tt.wantList, expandStringList(tt.input))
+		})
+	}
+}
+
 func TestFlattenStringListToSet(t *testing.T) {
 	tests := map[string]struct {
 		input    []string
@@ -193,3 +216,42 @@ func TestFlattenStringList(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandIntListFromSet(t *testing.T) {
+	tests := map[string]struct {
+		input    []interface{}
+		wantList []int
+	}{
+		"normal ints": {
+			input:    []interface{}{503, 429, 502},
+			wantList: []int{429, 502, 503},
+		},
+		"empty set": {
+			input:    []interface{}{},
+			wantList: nil,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			set := schema.NewSet(schema.HashInt, tt.input)
+			actual := expandIntListFromSet(set)
+			sort.Ints(actual)
+			assert.Equal(t, tt.wantList, actual)
+		})
+	}
+}
+
+func TestValueOrZero(t *testing.T) {
+	s := "abc"
+	assert.Equal(t, "abc", valueOrZero(&s))
+	assert.Equal(t, "", valueOrZero[string](nil))
+	assert.False(t, valueOrZero[bool](nil))
+}
+
+func TestPtrTo(t *testing.T) {
+	p := ptrTo("abc")
+	require.NotNil(t, p)
+	assert.Equal(t, "abc", *p)
+	assert.NotSame(t, ptrTo(1), ptrTo(1))
+}
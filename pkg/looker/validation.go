@@ -0,0 +1,86 @@
+package looker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxSuggestions is the number of valid names suggested for a name that doesn't exist.
+const maxSuggestions = 3
+
+// validateNamesExist returns an error naming every entry of names that is not one of the valid names,
+// together with the closest valid names, so that typos are caught at plan time.
+func validateNamesExist(attribute, kind string, names, valid []string) error {
+	var errs []error
+	for _, name := range names {
+		if contains(valid, name) {
+			continue
+		}
+		msg := fmt.Sprintf("%s: %s %q does not exist", attribute, kind, name)
+		if suggestions := closestNames(name, valid); len(suggestions) > 0 {
+			msg += fmt.Sprintf(", did you mean %s?", quoteJoin(suggestions))
+		}
+		errs = append(errs, errors.New(msg))
+	}
+	return errors.Join(errs...)
+}
+
+// closestNames returns the candidates within a small edit distance of name, closest first.
+func closestNames(name string, candidates []string) []string {
+	type match struct {
+		name     string
+		distance int
+	}
+
+	threshold := max(2, len(name)/3)
+	var matches []match
+	for _, candidate := range candidates {
+		if d := levenshtein(strings.ToLower(name), strings.ToLower(candidate)); d <= threshold {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+
+	names := make([]string, 0, maxSuggestions)
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// levenshtein returns the number of single character insertions, deletions and substitutions turning a into b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+func quoteJoin(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, " or ")
+}
@@ -0,0 +1,130 @@
+package looker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hirosassa/terraform-provider-looker/pkg/fakelooker"
+	apiclient "github.com/looker-open-source/sdk-codegen/go/sdk/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := map[string]struct {
+		a, b string
+		want int
+	}{
+		"equal":        {a: "access_data", b: "access_data", want: 0},
+		"empty":        {a: "", b: "abc", want: 3},
+		"substitution": {a: "see_looks", b: "see_lookz", want: 1},
+		"deletion":     {a: "acess_data", b: "access_data", want: 1},
+		"transposition counts twice": {
+			a: "see_lokos", b: "see_looks", want: 2,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			assert.Equal(t, tt.want, levenshtein(tt.a, tt.b))
+			assert.Equal(t, tt.want, levenshtein(tt.b, tt.a))
+		})
+	}
+}
+
+func TestValidateNamesExist(t *testing.T) {
+	valid := []string{"access_data", "see_looks", "see_lookml", "see_user_dashboards", "explore"}
+
+	tests := map[string]struct {
+		names   []string
+		wantErr string
+	}{
+		"all valid": {
+			names: []string{"access_data", "explore"},
+		},
+		"typo with suggestions": {
+			names:   []string{"access_data", "see_lokos"},
+			wantErr: `permissions: permission "see_lokos" does not exist, did you mean "see_looks" or "see_lookml"?`,
+		},
+		"unknown without suggestions": {
+			names:   []string{"manage_everything"},
+			wantErr: `permissions: permission "manage_everything" does not exist`,
+		},
+		"every bad entry is named": {
+			names: []string{"acess_data", "EXPLORE"},
+			wantErr: `permissions: permission "acess_data" does not exist, did you mean "access_data"?` + "\n" +
+				`permissions: permission "EXPLORE" does not exist, did you mean "explore"?`,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			err := validateNamesExist("permissions", "permission", tt.names, valid)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCustomizeDiff_NamesExist(t *testing.T) {
+	_, client := newTestClient(t)
+
+	connection := "db"
+	_, err := client.CreateConnection(apiclient.WriteDBConnection{Name: &connection}, nil)
+	require.NoError(t, err)
+	model := "ecommerce"
+	_, err = client.CreateLookmlModel(apiclient.WriteLookmlModel{Name: &model, ProjectName: &model, AllowedDbConnectionNames: &[]string{connection}}, nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		resource           string
+		config             map[string]interface{}
+		allowMissingModels bool
+		wantErr            string
+	}{
+		"valid permissions": {
+			resource: "looker_permission_set",
+			config:   map[string]interface{}{"name": "set", "permissions": []interface{}{"access_data", "see_looks"}},
+		},
+		"misspelled permission": {
+			resource: "looker_permission_set",
+			config:   map[string]interface{}{"name": "set", "permissions": []interface{}{"access_data", "see_lokos"}},
+			wantErr:  `permission "see_lokos" does not exist, did you mean "see_looks"`,
+		},
+		"valid models": {
+			resource: "looker_model_set",
+			config:   map[string]interface{}{"name": "set", "models": []interface{}{"ecommerce"}},
+		},
+		"built-in model": {
+			resource: "looker_model_set",
+			config:   map[string]interface{}{"name": "set", "models": []interface{}{fakelooker.SystemActivityModel}},
+		},
+		"misspelled model": {
+			resource: "looker_model_set",
+			config:   map[string]interface{}{"name": "set", "models": []interface{}{"ecomerce"}},
+			wantErr:  `model "ecomerce" does not exist, did you mean "ecommerce"?`,
+		},
+		"missing model allowed": {
+			resource:           "looker_model_set",
+			config:             map[string]interface{}{"name": "set", "models": []interface{}{"not_deployed_yet"}},
+			allowMissingModels: true,
+		},
+	}
+
+	for key, tt := range tests {
+		t.Run(key, func(t *testing.T) {
+			client.allowMissingModels = tt.allowMissingModels
+			r := Provider().ResourcesMap[tt.resource]
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.config), client)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}